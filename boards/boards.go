@@ -0,0 +1,296 @@
+package boards
+
+import (
+	"math/rand"
+
+	"github.com/mevdschee/fyne-mines/clips"
+)
+
+// the states of a game
+const (
+	StateWaiting = iota
+	StatePlaying
+	StateWon
+	StateLost
+)
+
+// the names of the frames of the button
+const (
+	ButtonPlaying  = "playing"
+	ButtonEvaluate = "evaluate"
+	ButtonLost     = "lost"
+	ButtonWon      = "won"
+	ButtonPressed  = "pressed"
+)
+
+// the names of the frames of the icons
+const (
+	IconEmpty        = "empty"
+	IconClosed       = "closed"
+	IconBomb         = "bomb"
+	IconMarked       = "marked"
+	IconAnswerNoBomb = "answerNoBomb"
+	IconAnswerIsBomb = "answerIsBomb"
+)
+
+// NumberIcons are the names of the frames of open tiles by their number
+var NumberIcons = []string{IconEmpty, "one", "two", "three", "four", "five", "six", "seven", "eight"}
+
+// Tile is a tile of the board
+type Tile struct {
+	Open    bool
+	Marked  bool
+	Bomb    bool
+	Pressed bool
+	Number  int
+}
+
+// Board is a game of minesweeper, the bombs are placed when the first tile
+// is opened so that it never has a bomb
+type Board struct {
+	width, height int
+	bombs         int
+	remaining     int
+	closed        int
+	state         int
+	tiles         [][]Tile
+	rng           *rand.Rand
+}
+
+// New creates a new board without bombs, they are placed at random with
+// the random source when the first tile is opened
+func New(width, height, bombs int, rng *rand.Rand) *Board {
+	b := &Board{
+		width:     width,
+		height:    height,
+		bombs:     bombs,
+		remaining: bombs,
+		closed:    width * height,
+		state:     StateWaiting,
+		tiles:     make([][]Tile, height),
+		rng:       rng,
+	}
+	for y := range b.tiles {
+		b.tiles[y] = make([]Tile, width)
+	}
+	return b
+}
+
+// GetWidth gets the number of columns of the board
+func (b *Board) GetWidth() int {
+	return b.width
+}
+
+// GetHeight gets the number of rows of the board
+func (b *Board) GetHeight() int {
+	return b.height
+}
+
+// GetState gets the state of the game
+func (b *Board) GetState() int {
+	return b.state
+}
+
+// IsOver returns whether the game is won or lost
+func (b *Board) IsOver() bool {
+	return b.state == StateWon || b.state == StateLost
+}
+
+// GetRemaining gets the number of bombs that are not marked, as the digits
+// show it
+func (b *Board) GetRemaining() int {
+	return b.remaining
+}
+
+// GetButton gets the name of the frame of the button for the state of the
+// game
+func (b *Board) GetButton() string {
+	switch b.state {
+	case StateWon:
+		return ButtonWon
+	case StateLost:
+		return ButtonLost
+	}
+	return ButtonPlaying
+}
+
+// GetTile gets a tile of the board
+func (b *Board) GetTile(x, y int) Tile {
+	return b.tiles[y][x]
+}
+
+// SetPressed shows a tile as pressed or not
+func (b *Board) SetPressed(x, y int, pressed bool) {
+	b.tiles[y][x].Pressed = pressed
+}
+
+// ForEachNeighbour calls a function for the tiles around a tile
+func (b *Board) ForEachNeighbour(x, y int, do func(x, y int)) {
+	for i := 0; i < 9; i++ {
+		dy, dx := i/3-1, i%3-1
+		if dy == 0 && dx == 0 {
+			continue
+		}
+		if y+dy < 0 || x+dx < 0 {
+			continue
+		}
+		if y+dy >= b.height || x+dx >= b.width {
+			continue
+		}
+		do(x+dx, y+dy)
+	}
+}
+
+// Dig opens a closed tile or opens the neighbours of an open tile, it
+// returns whether any tile was opened
+func (b *Board) Dig(x, y int) bool {
+	if b.tiles[y][x].Marked {
+		return false
+	}
+	if b.tiles[y][x].Open {
+		return b.Chord(x, y)
+	}
+	b.open(x, y)
+	return true
+}
+
+// Chord opens the unmarked neighbours of an open tile when the number of
+// marked neighbours matches its number and returns whether it did
+func (b *Board) Chord(px, py int) bool {
+	marks := 0
+	b.ForEachNeighbour(px, py, func(x, y int) {
+		if b.tiles[y][x].Marked {
+			marks++
+		}
+	})
+	if b.tiles[py][px].Number != marks {
+		return false
+	}
+	b.ForEachNeighbour(px, py, func(x, y int) {
+		if !b.tiles[y][x].Open && !b.tiles[y][x].Marked {
+			b.open(x, y)
+		}
+	})
+	return true
+}
+
+// ToggleMark marks or unmarks a closed tile as a bomb
+func (b *Board) ToggleMark(x, y int) {
+	if b.tiles[y][x].Open {
+		return
+	}
+	if b.tiles[y][x].Marked {
+		b.tiles[y][x].Marked = false
+		b.remaining++
+	} else {
+		b.tiles[y][x].Marked = true
+		b.remaining--
+	}
+}
+
+// open opens a tile, and the tiles around it when it has no bombs around
+func (b *Board) open(x, y int) {
+	if b.state == StateWaiting {
+		b.state = StatePlaying
+		b.placeBombs(x, y)
+	}
+	if b.tiles[y][x].Open || b.tiles[y][x].Marked {
+		return
+	}
+	b.tiles[y][x].Open = true
+	b.closed--
+	if b.tiles[y][x].Bomb {
+		b.state = StateLost
+		return
+	}
+	if b.closed == b.bombs {
+		b.state = StateWon
+		b.remaining = 0
+		return
+	}
+	if b.tiles[y][x].Number == 0 {
+		b.ForEachNeighbour(x, y, func(px, py int) {
+			b.open(px, py)
+		})
+	}
+}
+
+// placeBombs places the bombs at random, but not on the first opened tile
+func (b *Board) placeBombs(x, y int) {
+	left := b.bombs
+	b.tiles[y][x].Bomb = true
+	for left > 0 {
+		x, y := b.rng.Intn(b.width), b.rng.Intn(b.height)
+		if !b.tiles[y][x].Bomb {
+			b.tiles[y][x].Bomb = true
+			left--
+			b.ForEachNeighbour(x, y, func(x, y int) {
+				b.tiles[y][x].Number++
+			})
+		}
+	}
+	b.tiles[y][x].Bomb = false
+}
+
+// GetIcon gets the name of the icon that shows a tile, when the game is
+// over the bombs are revealed
+func (b *Board) GetIcon(x, y int) string {
+	t := b.tiles[y][x]
+	if b.IsOver() {
+		switch {
+		case t.Open && t.Bomb:
+			return IconAnswerIsBomb
+		case t.Open:
+			return NumberIcons[t.Number]
+		case t.Marked && t.Bomb:
+			return IconMarked
+		case t.Marked:
+			return IconAnswerNoBomb
+		case t.Bomb && b.state == StateWon:
+			return IconMarked
+		case t.Bomb:
+			return IconBomb
+		}
+		return IconClosed
+	}
+	switch {
+	case t.Open:
+		return NumberIcons[t.Number]
+	case t.Marked:
+		return IconMarked
+	case t.Pressed:
+		return IconEmpty
+	}
+	return IconClosed
+}
+
+// GetIcons gets the names of the icons of all tiles, row by row
+func (b *Board) GetIcons() []string {
+	icons := make([]string, 0, b.width*b.height)
+	for y := 0; y < b.height; y++ {
+		for x := 0; x < b.width; x++ {
+			icons = append(icons, b.GetIcon(x, y))
+		}
+	}
+	return icons
+}
+
+// Show shows the icons of all tiles on the clips of the board, row by row,
+// the clips are not refreshed
+func (b *Board) Show(icons []*clips.Clip) error {
+	var first error
+	for y := 0; y < b.height; y++ {
+		for x := 0; x < b.width; x++ {
+			err := icons[y*b.width+x].GotoFrameByName(b.GetIcon(x, y), false)
+			if err != nil && first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// ShowTile shows the icon of a tile on its clip and refreshes it
+func (b *Board) ShowTile(icons []*clips.Clip, x, y int) error {
+	return icons[y*b.width+x].GotoFrameByName(b.GetIcon(x, y), true)
+}
@@ -0,0 +1,204 @@
+package boards
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/mevdschee/fyne-mines/layers"
+	"github.com/mevdschee/fyne-mines/movies"
+	"github.com/mevdschee/fyne-mines/sprites"
+)
+
+var update = flag.Bool("update", false, "update the golden images in testdata")
+
+// the difficulties of the game
+var difficulties = []struct {
+	name                 string
+	width, height, bombs int
+}{
+	{"beginner", 9, 9, 10},
+	{"intermediate", 16, 16, 40},
+	{"expert", 30, 16, 99},
+}
+
+// the games that are rendered, each is played on a new board
+var games = []struct {
+	name  string
+	state int
+	play  func(t *testing.T, b *Board)
+}{
+	{"waiting", StateWaiting, func(t *testing.T, b *Board) {}},
+	{"playing", StatePlaying, func(t *testing.T, b *Board) {
+		b.Dig(b.width/2, b.height/2)
+		b.ToggleMark(findTile(t, b, isClosedBomb))
+		b.ToggleMark(findTile(t, b, isClosedSafe))
+		x, y := findTile(t, b, isClosedSafe)
+		b.SetPressed(x, y, true)
+	}},
+	{"won", StateWon, func(t *testing.T, b *Board) {
+		b.Dig(b.width/2, b.height/2)
+		b.ToggleMark(findTile(t, b, isClosedBomb))
+		for !b.IsOver() {
+			b.Dig(findTile(t, b, isClosedSafe))
+		}
+	}},
+	{"lost", StateLost, func(t *testing.T, b *Board) {
+		b.Dig(b.width/2, b.height/2)
+		b.ToggleMark(findTile(t, b, isClosedBomb))
+		b.ToggleMark(findTile(t, b, isClosedSafe))
+		b.Dig(findTile(t, b, isClosedBomb))
+	}},
+}
+
+func isClosedBomb(t Tile) bool {
+	return !t.Open && !t.Marked && t.Bomb
+}
+
+func isClosedSafe(t Tile) bool {
+	return !t.Open && !t.Marked && !t.Bomb
+}
+
+// findTile finds the first tile of the board that matches
+func findTile(t *testing.T, b *Board, matches func(t Tile) bool) (int, int) {
+	t.Helper()
+	for y := 0; y < b.height; y++ {
+		for x := 0; x < b.width; x++ {
+			if matches(b.tiles[y][x]) {
+				return x, y
+			}
+		}
+	}
+	t.Fatal("no tile found")
+	return 0, 0
+}
+
+func readSkin(t *testing.T) (sprites.SpriteMap, string) {
+	imageData, err := os.ReadFile("../winxpskin.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta, err := os.ReadFile("../skin/sprites.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile("../skin/movie.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spriteMap, err := sprites.NewSpriteMap(imageData, string(meta))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return spriteMap, string(data)
+}
+
+// TestRender plays games on the boards of every difficulty, shows them on
+// the movie of the game as the game does and compares the rendered game
+// with the golden images, run the test with -update to write them
+func TestRender(t *testing.T) {
+	test.NewApp()
+	spriteMap, data := readSkin(t)
+	for _, difficulty := range difficulties {
+		parameters := map[string]interface{}{"w": difficulty.width, "h": difficulty.height, "s": 1}
+		m, err := movies.FromJSON(spriteMap, data, parameters, layers.NewFunctions())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, game := range games {
+			b := New(difficulty.width, difficulty.height, difficulty.bombs, rand.New(rand.NewSource(1)))
+			game.play(t, b)
+			if b.GetState() != game.state {
+				t.Fatalf("%s %s: game is in state %d, want %d", difficulty.name, game.name, b.GetState(), game.state)
+			}
+			seconds := 42
+			if b.GetState() == StateWaiting {
+				seconds = 0
+			}
+			values := map[string]interface{}{"button": b.GetButton(), "bombs": b.GetRemaining(), "seconds": seconds}
+			for name, value := range values {
+				err = m.Set(name, value)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			icons, err := m.GetClips("game", "board", "icons")
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = b.Show(icons)
+			if err != nil {
+				t.Fatal(err)
+			}
+			img, err := m.Render("game")
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, fmt.Sprintf("game_%s_%s.png", difficulty.name, game.name), img)
+		}
+	}
+}
+
+// TestLostIcons checks that a lost game reveals every kind of tile
+func TestLostIcons(t *testing.T) {
+	for _, difficulty := range difficulties {
+		b := New(difficulty.width, difficulty.height, difficulty.bombs, rand.New(rand.NewSource(1)))
+		games[3].play(t, b)
+		shown := map[string]bool{}
+		for _, icon := range b.GetIcons() {
+			shown[icon] = true
+		}
+		for _, icon := range []string{IconAnswerIsBomb, IconAnswerNoBomb, IconMarked, IconBomb, IconClosed, NumberIcons[1]} {
+			if !shown[icon] {
+				t.Errorf("%s: lost game does not show '%s'", difficulty.name, icon)
+			}
+		}
+	}
+}
+
+func checkGolden(t *testing.T, name string, img image.Image) {
+	t.Helper()
+	filename := filepath.Join("testdata", name)
+	buf := &bytes.Buffer{}
+	err := png.Encode(buf, img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		err = os.WriteFile(filename, buf.Bytes(), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("%v (run the test with -update to write it)", err)
+	}
+	golden, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !golden.Bounds().Eq(img.Bounds()) {
+		t.Errorf("%s: rendered at %v, golden image is %v", name, img.Bounds(), golden.Bounds())
+		return
+	}
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r1, g1, b1, a1 := img.At(x, y).RGBA()
+			r2, g2, b2, a2 := golden.At(x, y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				t.Errorf("%s: differs from the golden image at (%d,%d)", name, x, y)
+				return
+			}
+		}
+	}
+}
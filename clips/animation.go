@@ -0,0 +1,205 @@
+package clips
+
+import (
+	"time"
+)
+
+// LoopMode defines what happens when an animation reaches its last frame
+type LoopMode int
+
+const (
+	// LoopNone stops on the last frame
+	LoopNone LoopMode = iota
+	// LoopForward starts again at the first frame
+	LoopForward
+	// LoopPingPong plays the frames backwards and then forwards again
+	LoopPingPong
+)
+
+// defaultFrameRate is used for frames that have no duration
+const defaultFrameRate = 10
+
+// animation is the timeline of a clip
+type animation struct {
+	sequence   []int
+	durations  []time.Duration
+	loop       LoopMode
+	playing    bool
+	step       int
+	direction  int
+	elapsed    time.Duration
+	onComplete func()
+}
+
+// SetFrameRate sets the duration of every frame of the clip
+func (c *Clip) SetFrameRate(fps int) {
+	if fps <= 0 {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for i := range c.durations {
+		c.durations[i] = time.Second / time.Duration(fps)
+	}
+	for i := range c.animation.durations {
+		c.animation.durations[i] = time.Second / time.Duration(fps)
+	}
+}
+
+// SetSequence sets the frames that are played and their durations, when
+// durations is nil the durations of the frames are used
+func (c *Clip) SetSequence(frames []int, durations []time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.setSequence(frames, durations)
+}
+
+func (c *Clip) setSequence(frames []int, durations []time.Duration) {
+	a := &c.animation
+	a.sequence = []int{}
+	a.durations = []time.Duration{}
+	for i, frame := range frames {
+		if frame < 0 || frame >= len(c.frames) {
+			continue
+		}
+		duration := c.durations[frame]
+		if i < len(durations) && durations[i] > 0 {
+			duration = durations[i]
+		}
+		a.sequence = append(a.sequence, frame)
+		a.durations = append(a.durations, duration)
+	}
+	a.step = 0
+	a.direction = 1
+	a.elapsed = 0
+}
+
+// SetLoop sets the loop mode of the animation
+func (c *Clip) SetLoop(mode LoopMode) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.animation.loop = mode
+}
+
+// OnComplete sets the handler that is called when the animation stops at its last frame
+func (c *Clip) OnComplete(handler func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.animation.onComplete = handler
+}
+
+// IsPlaying returns whether the animation is playing
+func (c *Clip) IsPlaying() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.animation.playing
+}
+
+// Play plays the animation from the current frame
+func (c *Clip) Play() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.play()
+}
+
+func (c *Clip) play() {
+	if len(c.animation.sequence) == 0 {
+		c.setSequence(c.allFrames(), nil)
+	}
+	if len(c.animation.sequence) < 2 {
+		return
+	}
+	c.animation.playing = true
+	startTicking(c)
+}
+
+// Stop stops the animation at the current frame
+func (c *Clip) Stop() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.animation.playing = false
+	stopTicking(c)
+}
+
+// GotoAndPlay goes to a frame of the clip and plays the animation from there
+func (c *Clip) GotoAndPlay(frame int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.animation.sequence) == 0 {
+		c.setSequence(c.allFrames(), nil)
+	}
+	a := &c.animation
+	for i, f := range a.sequence {
+		if f == frame {
+			a.step = i
+			a.direction = 1
+			a.elapsed = 0
+			break
+		}
+	}
+	c.gotoFrame(frame, true)
+	c.play()
+}
+
+func (c *Clip) allFrames() []int {
+	frames := make([]int, len(c.frames))
+	for i := range frames {
+		frames[i] = i
+	}
+	return frames
+}
+
+// tick advances the animation, it is called by the shared ticker, the
+// complete handler is called without holding the lock of the clip so that
+// it may play the animation again
+func (c *Clip) tick(elapsed time.Duration) bool {
+	c.mutex.Lock()
+	a := &c.animation
+	if !a.playing || len(a.sequence) == 0 {
+		c.mutex.Unlock()
+		return false
+	}
+	a.elapsed += elapsed
+	for a.elapsed >= a.durations[a.step] {
+		a.elapsed -= a.durations[a.step]
+		if !c.nextStep() {
+			a.playing = false
+			a.elapsed = 0
+			c.gotoFrame(a.sequence[a.step], true)
+			onComplete := a.onComplete
+			c.mutex.Unlock()
+			if onComplete != nil {
+				onComplete()
+			}
+			return c.IsPlaying()
+		}
+	}
+	c.gotoFrame(a.sequence[a.step], true)
+	c.mutex.Unlock()
+	return true
+}
+
+// nextStep moves to the next step in the sequence, it returns false when
+// the end of the sequence is reached and the animation does not loop
+func (c *Clip) nextStep() bool {
+	a := &c.animation
+	last := len(a.sequence) - 1
+	next := a.step + a.direction
+	if next >= 0 && next <= last {
+		a.step = next
+		return true
+	}
+	switch a.loop {
+	case LoopForward:
+		a.step = 0
+	case LoopPingPong:
+		a.direction = -a.direction
+		a.step += a.direction
+		if a.step < 0 || a.step > last {
+			a.step = 0
+		}
+	default:
+		return false
+	}
+	return true
+}
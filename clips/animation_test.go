@@ -0,0 +1,98 @@
+package clips
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/mevdschee/fyne-mines/sprites"
+)
+
+// newTestSprite creates a sprite of a number of 4x4 frames
+func newTestSprite(count int) *sprites.Sprite {
+	var img image.Image = image.NewNRGBA(image.Rect(0, 0, 4*count, 4))
+	sprite := &sprites.Sprite{Image: &img, Name: "test", Width: 4, Height: 4, Count: count, Grid: count}
+	for i := 0; i < count; i++ {
+		sprite.Frames = append(sprite.Frames, &sprites.Frame{X: i * 4, Width: 4, Height: 4})
+	}
+	return sprite
+}
+
+// manualTicker makes the shared ticker manual for the test, it waits for the
+// goroutine of the ticker to stop so that only step advances the clips
+func manualTicker(t *testing.T) {
+	ticker.Lock()
+	ticker.manual = true
+	ticker.items = map[tickable]bool{}
+	ticker.Unlock()
+	ticker.done.Wait()
+	t.Cleanup(func() {
+		ticker.Lock()
+		ticker.manual = false
+		ticker.items = map[tickable]bool{}
+		ticker.Unlock()
+	})
+}
+
+// isTicking returns whether the shared ticker ticks an item
+func isTicking(item tickable) bool {
+	ticker.Lock()
+	defer ticker.Unlock()
+	return ticker.items[item]
+}
+
+// TestAnimationWhileTicking changes a playing clip while the ticker steps
+// it from another goroutine
+func TestAnimationWhileTicking(t *testing.T) {
+	test.NewApp()
+	manualTicker(t)
+	clip := New(newTestSprite(4), "clip", 0, 0, 1)
+	clip.SetFrameRate(1000)
+	clip.SetLoop(LoopForward)
+	clip.Play()
+	clip.Tween(50*time.Millisecond, Linear).MoveTo(10, 10).FadeTo(0.5).Start()
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 100; i++ {
+			step(time.Millisecond)
+		}
+		close(done)
+	}()
+	for i := 0; i < 100; i++ {
+		clip.GotoFrame(i%4, true)
+		clip.Stop()
+		clip.GotoAndPlay((i + 1) % 4)
+		clip.GetBounds()
+	}
+	<-done
+	step(50 * time.Millisecond)
+	clip.Stop()
+	if clip.IsPlaying() {
+		t.Error("clip is playing after stop")
+	}
+	if bounds := clip.GetBounds(); bounds.Min != image.Pt(10, 10) {
+		t.Errorf("clip at %v after tween, want (10,10)", bounds.Min)
+	}
+}
+
+func TestAnimationComplete(t *testing.T) {
+	test.NewApp()
+	manualTicker(t)
+	clip := New(newTestSprite(3), "clip", 0, 0, 1)
+	clip.SetFrameRate(1000)
+	completed := []int{}
+	clip.OnComplete(func() {
+		completed = append(completed, clip.GetFrame())
+	})
+	clip.Play()
+	for i := 0; i < 5; i++ {
+		step(time.Millisecond)
+	}
+	if len(completed) != 1 || completed[0] != 2 {
+		t.Errorf("completed on frames %v, want [2]", completed)
+	}
+	if clip.IsPlaying() || isTicking(clip) {
+		t.Error("clip is playing after it completed")
+	}
+}
@@ -0,0 +1,63 @@
+package clips
+
+import (
+	"fmt"
+	"time"
+)
+
+// the names of the events that can be bound in JSON
+const (
+	EventPress   = "press"
+	EventRelease = "release"
+	EventEnter   = "enter"
+	EventLeave   = "leave"
+	EventOver    = "over"
+	EventTap     = "tap"
+)
+
+// Events are the names of the events that can be bound in JSON
+var Events = []string{EventPress, EventRelease, EventEnter, EventLeave, EventOver, EventTap}
+
+// GetIndex gets the repeat index of the clip
+func (c *Clip) GetIndex() int {
+	return c.index
+}
+
+// SetIndex sets the repeat index of the clip
+func (c *Clip) SetIndex(i int) {
+	c.index = i
+}
+
+// GetActions gets the names of the actions bound to events of the clip by name
+func (c *Clip) GetActions() map[string]string {
+	return c.actions
+}
+
+// SetActions sets the names of the actions bound to events of the clip by name
+func (c *Clip) SetActions(actions map[string]string) {
+	c.actions = actions
+}
+
+// Bind sets the handler of an event by its name, a handler that is set
+// later with one of the On methods replaces it
+func (c *Clip) Bind(event string, handler func(ev *Event)) error {
+	switch event {
+	case EventPress:
+		c.OnPressEvent(handler)
+	case EventRelease:
+		c.OnReleaseEvent(handler)
+	case EventEnter:
+		c.OnEnterEvent(handler)
+	case EventLeave:
+		c.OnLeave(func() {
+			handler(&Event{Time: time.Now()})
+		})
+	case EventOver:
+		c.OnOverEvent(handler)
+	case EventTap:
+		c.OnTapEvent(handler)
+	default:
+		return fmt.Errorf("unknown event '%s'", event)
+	}
+	return nil
+}
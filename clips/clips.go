@@ -1,7 +1,12 @@
 package clips
 
 import (
+	"fmt"
 	"image"
+	"image/color"
+	"math"
+	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -12,32 +17,77 @@ import (
 	"golang.org/x/image/draw"
 )
 
-// Clip is a set of frames
+// Clip is a set of frames, the mutex guards the frame, the animation and the
+// place of the clip as these are also changed by the shared ticker
 type Clip struct {
-	container     *fyne.Container
-	name          string
-	x, y          int
-	width, height int
-	scale         int
-	overlay       *interactive.Image
-	frame         int
-	frames        []*canvas.Image
-	onPress       func(left, right, middle, alt, control bool)
-	onRelease     func(left, right, middle, alt, control bool)
-	onEnter       func(left, right, middle, alt, control bool)
-	onLeave       func()
-	onOver        func(left, right, middle, alt, control bool)
+	mutex          sync.Mutex
+	container      *fyne.Container
+	name           string
+	sprite         *sprites.Sprite
+	scaled         bool
+	x, y           int
+	width, height  int
+	scale          int
+	overlay        *interactive.Image
+	frame          int
+	frames         []*canvas.Image
+	names          map[string]int
+	durations      []time.Duration
+	animation      animation
+	opacity        float64
+	layerOpacity   float64
+	highlight      color.Color
+	tweens         []*Tween
+	tilemap        *tilemap
+	text           *text
+	onPress        func(ev *Event)
+	onRelease      func(ev *Event)
+	onEnter        func(ev *Event)
+	onLeave        func()
+	onOver         func(ev *Event)
+	onTap          func(ev *Event)
+	onTapSecondary func(ev *Event)
+	onLongPress    func(ev *Event)
+	dragArea       *interactive.DragArea
+	scrollArea     *interactive.ScrollArea
+	doubleTap      *interactive.DoubleTapImage
+	onDrag         func(ev *Event)
+	onDragEnd      func()
+	onScroll       func(ev *Event)
+	onDoubleClick  func(ev *Event)
+	focusArea      *interactive.FocusArea
+	onKey          func(ev *Event) bool
+	onFocus        func(focused bool)
+	parent         KeyHandler
+	index          int
+	actions        map[string]string
 }
 
 // ClipJSON is a clip in JSON
 type ClipJSON struct {
 	Name          string
+	Type          string
 	Sprite        string
 	Repeat        string
 	X, Y          string
 	Width, Height string
+	Columns, Rows string
+	On            map[string]string
+	If            string
+	Frame         string
+	Visible       string
+	Opacity       string
+	Text          string
+	Align         string
+	Font          string
+	Size          string
+	Color         string
+	Focusable     bool
 }
 
+// TypeTilemap is the type of a clip that draws a grid of frames into one image
+const TypeTilemap = "tilemap"
+
 // GetName gets the name of the clip
 func (c *Clip) GetName() string {
 	return c.name
@@ -50,48 +100,169 @@ func (c *Clip) GetContainer() *fyne.Container {
 
 // GetPosition gets the position of the clip
 func (c *Clip) GetPosition() fyne.Position {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.position()
+}
+
+func (c *Clip) position() fyne.Position {
 	return fyne.Position{X: float32(c.x * c.scale), Y: float32(c.y * c.scale)}
 }
 
 // GetSize gets the size of the clip
 func (c *Clip) GetSize() fyne.Size {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.size()
+}
+
+func (c *Clip) size() fyne.Size {
 	return fyne.Size{Width: float32(c.width * c.scale), Height: float32(c.height * c.scale)}
 }
 
+// Move moves the clip to a position (in unscaled pixels)
+func (c *Clip) Move(x, y int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.text != nil && c.text.fit {
+		c.text.anchor = x
+		x = c.text.left(c.width)
+	}
+	c.x, c.y = x, y
+	c.container.Move(c.position())
+}
+
+// GetScale gets the scale of the clip
+func (c *Clip) GetScale() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.scale
+}
+
+// SetScale sets the scale of the clip, it is moved and resized accordingly
+func (c *Clip) SetScale(scale int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.scale = scale
+	if c.text != nil && c.text.label != nil {
+		c.text.label.TextSize = float32(c.text.size * scale)
+		c.text.label.Refresh()
+	}
+	c.container.Move(c.position())
+	c.container.Resize(c.size())
+}
+
+// Resize resizes the clip (in unscaled pixels)
+func (c *Clip) Resize(width, height int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.width, c.height = width, height
+	c.container.Resize(c.size())
+}
+
+// GetOpacity gets the opacity of the clip
+func (c *Clip) GetOpacity() float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.opacity
+}
+
+// SetOpacity sets the opacity of the clip (from 0 to 1)
+func (c *Clip) SetOpacity(opacity float64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	opacity = math.Max(0, math.Min(1, opacity))
+	if c.opacity == opacity {
+		return
+	}
+	c.opacity = opacity
+	c.applyOpacity()
+}
+
+// applyOpacity makes the frames as translucent as the opacity of the clip
+// and that of its layer ask for
+func (c *Clip) applyOpacity() {
+	for _, frame := range c.frames {
+		frame.Translucency = 1 - c.opacity*c.layerOpacity
+	}
+	c.frames[c.frame].Refresh()
+	if c.tilemap != nil {
+		c.tilemap.applyOpacity(1 - c.opacity*c.layerOpacity)
+	}
+	if c.text != nil {
+		c.text.applyOpacity(c.opacity * c.layerOpacity)
+	}
+}
+
+// GetHighlight gets the color that is drawn over the clip, nil if none
+func (c *Clip) GetHighlight() color.Color {
+	return c.highlight
+}
+
+// SetHighlight draws a (translucent) color over the clip, nil removes it,
+// only the clip is refreshed
+func (c *Clip) SetHighlight(highlight color.Color) {
+	if c.highlight == highlight {
+		return
+	}
+	c.highlight = highlight
+	overlay, ok := c.overlay.Image.Image.(*image.NRGBA)
+	if !ok {
+		return
+	}
+	if highlight == nil {
+		highlight = color.Transparent
+	}
+	draw.Draw(overlay, overlay.Bounds(), &image.Uniform{highlight}, image.Point{0, 0}, draw.Src)
+	c.overlay.Refresh()
+}
+
+// SetCursor sets the mouse cursor that is shown over the clip
+func (c *Clip) SetCursor(cursor desktop.Cursor) {
+	c.overlay.SetCursor(cursor)
+}
+
 // New creates a new sprite based clip
 func New(sprite *sprites.Sprite, name string, x, y, scale int) *Clip {
 	frames := []*canvas.Image{}
+	names := map[string]int{}
+	durations := []time.Duration{}
 
 	srcWidth, srcHeight := sprite.Width, sprite.Height
-	for i := 0; i < sprite.Count; i++ {
-		grid := sprite.Grid
-		if grid == 0 {
-			grid = sprite.Count
+	for i, f := range sprite.GetFrames() {
+		if f.Name != "" {
+			names[f.Name] = i
 		}
-		srcX := sprite.X + (i%grid)*(srcWidth+sprite.Gap)
-		srcY := sprite.Y + (i/grid)*(srcHeight+sprite.Gap)
-		srcRect := image.Rect(srcX, srcY, srcX+srcWidth, srcY+srcHeight)
-		dstRect := image.Rect(0, 0, srcWidth, srcHeight)
-		dst := image.NewNRGBA(dstRect)
-		draw.Copy(dst, image.Point{}, *sprite.Image, srcRect, draw.Over, nil)
+		dst := image.NewNRGBA(image.Rect(0, 0, srcWidth, srcHeight))
+		drawFrame(dst, *sprite.Image, f)
 		frame := canvas.NewImageFromImage(dst)
 		frame.ScaleMode = canvas.ImageScalePixels
 		frames = append(frames, frame)
+		duration := time.Second / defaultFrameRate
+		if f.Duration > 0 {
+			duration = time.Duration(f.Duration) * time.Millisecond
+		}
+		durations = append(durations, duration)
 	}
 	overlay := image.NewNRGBA(frames[0].Image.Bounds())
 	//blue := color.RGBA{0, 0, 255, 200}
 	//draw.Draw(overlay, overlay.Bounds(), &image.Uniform{blue}, image.Point{0, 0}, draw.Src)
 	clip := &Clip{
-		container: container.NewStack(),
-		name:      name,
-		x:         x,
-		y:         y,
-		width:     srcWidth,
-		height:    srcHeight,
-		scale:     scale,
-		overlay:   interactive.NewImage(canvas.NewImageFromImage(overlay)),
-		frame:     0,
-		frames:    frames,
+		container:    container.NewStack(),
+		name:         name,
+		sprite:       sprite,
+		x:            x,
+		y:            y,
+		width:        srcWidth,
+		height:       srcHeight,
+		scale:        scale,
+		opacity:      1,
+		layerOpacity: 1,
+		overlay:      interactive.NewImage(canvas.NewImageFromImage(overlay)),
+		frame:        0,
+		frames:       frames,
+		names:        names,
+		durations:    durations,
 	}
 	for i := 0; i < len(clip.frames); i++ {
 		if i == clip.frame {
@@ -105,11 +276,75 @@ func New(sprite *sprites.Sprite, name string, x, y, scale int) *Clip {
 	return clip
 }
 
-// NewScaled creates a new 9 slice scaled sprite based clip
+// drawFrame draws a frame from the sprite sheet at its offset, turning it
+// back 90 degrees counter clockwise when it was stored rotated
+func drawFrame(dst *image.NRGBA, src image.Image, f *sprites.Frame) {
+	if !f.Rotated {
+		srcRect := image.Rect(f.X, f.Y, f.X+f.Width, f.Y+f.Height)
+		draw.Copy(dst, image.Point{f.OffsetX, f.OffsetY}, src, srcRect, draw.Over, nil)
+		return
+	}
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			dst.Set(f.OffsetX+x, f.OffsetY+y, src.At(f.X+f.Height-1-y, f.Y+x))
+		}
+	}
+}
+
+// NewScaled creates a new 9 slice scaled sprite based clip, it has a frame
+// for each set of 9 slices of the sprite
 func NewScaled(sprite *sprites.Sprite, name string, x, y, width, height, scale int) *Clip {
+	frames := []*canvas.Image{}
+	names := map[string]int{}
+	durations := []time.Duration{}
+	for i, f := range sprite.GetSlicedFrames() {
+		if f.Name != "" {
+			names[f.Name] = i
+		}
+		frame := canvas.NewImageFromImage(drawScaled(sprite, f, width, height))
+		frame.ScaleMode = canvas.ImageScalePixels
+		frames = append(frames, frame)
+		durations = append(durations, time.Second/defaultFrameRate)
+	}
+	overlay := image.NewNRGBA(image.Rect(0, 0, width, height))
+	//blue := color.RGBA{0, 0, 255, 200}
+	//draw.Draw(overlay, overlay.Bounds(), &image.Uniform{blue}, image.Point{0, 0}, draw.Src)
+	clip := &Clip{
+		container:    container.NewStack(),
+		name:         name,
+		sprite:       sprite,
+		x:            x,
+		y:            y,
+		width:        width,
+		height:       height,
+		scale:        scale,
+		opacity:      1,
+		layerOpacity: 1,
+		overlay:      interactive.NewImage(canvas.NewImageFromImage(overlay)),
+		frame:        0,
+		frames:       frames,
+		names:        names,
+		durations:    durations,
+		scaled:       true,
+	}
+	for i := 0; i < len(clip.frames); i++ {
+		if i == clip.frame {
+			clip.frames[i].Show()
+		} else {
+			clip.frames[i].Hide()
+		}
+		clip.container.Add(clip.frames[i])
+	}
+	clip.container.Add(clip.overlay)
+	return clip
+}
+
+// drawScaled draws the 9 slices of a frame of a sprite at a size (in
+// unscaled pixels), the edges and the centre are stretched or tiled
+func drawScaled(sprite *sprites.Sprite, f *sprites.Frame, width, height int) *image.NRGBA {
 	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
 
-	srcY := sprite.Y
+	srcY := f.Y
 	dstY := 0
 	for h := 0; h < 3; h++ {
 		srcHeight := sprite.Heights[h]
@@ -117,7 +352,7 @@ func NewScaled(sprite *sprites.Sprite, name string, x, y, width, height, scale i
 		if h == 1 {
 			dstHeight = height - sprite.Heights[0] - sprite.Heights[2]
 		}
-		srcX := sprite.X
+		srcX := f.X
 		dstX := 0
 		for w := 0; w < 3; w++ {
 			srcWidth := sprite.Widths[w]
@@ -128,7 +363,11 @@ func NewScaled(sprite *sprites.Sprite, name string, x, y, width, height, scale i
 
 			srcRect := image.Rect(srcX, srcY, srcX+srcWidth, srcY+srcHeight)
 			dstRect := image.Rect(dstX, dstY, dstX+dstWidth, dstY+dstHeight)
-			draw.NearestNeighbor.Scale(dst, dstRect, *sprite.Image, srcRect, draw.Over, nil)
+			if sprite.Fill == sprites.FillTile {
+				tile(dst, dstRect, *sprite.Image, srcRect)
+			} else {
+				draw.NearestNeighbor.Scale(dst, dstRect, *sprite.Image, srcRect, draw.Over, nil)
+			}
 
 			srcX += srcWidth + sprite.Gap
 			dstX += dstWidth
@@ -136,30 +375,64 @@ func NewScaled(sprite *sprites.Sprite, name string, x, y, width, height, scale i
 		srcY += srcHeight + sprite.Gap
 		dstY += dstHeight
 	}
-	frame0 := canvas.NewImageFromImage(dst)
-	frame0.ScaleMode = canvas.ImageScalePixels
-	overlay := image.NewNRGBA(frame0.Image.Bounds())
-	//blue := color.RGBA{0, 0, 255, 200}
-	//draw.Draw(overlay, overlay.Bounds(), &image.Uniform{blue}, image.Point{0, 0}, draw.Src)
-	clip := &Clip{
-		container: container.NewStack(),
-		name:      name,
-		x:         x,
-		y:         y,
-		width:     width,
-		height:    height,
-		scale:     scale,
-		overlay:   interactive.NewImage(canvas.NewImageFromImage(overlay)),
-		frame:     0,
-		frames:    []*canvas.Image{frame0},
-	}
-	clip.container.Add(frame0)
-	clip.container.Add(clip.overlay)
-	return clip
+	return dst
+}
+
+// tile repeats a rectangle of the sprite sheet over a rectangle, starting
+// at its top left corner
+func tile(dst *image.NRGBA, dstRect image.Rectangle, src image.Image, srcRect image.Rectangle) {
+	if srcRect.Empty() {
+		return
+	}
+	for y := dstRect.Min.Y; y < dstRect.Max.Y; y += srcRect.Dy() {
+		for x := dstRect.Min.X; x < dstRect.Max.X; x += srcRect.Dx() {
+			rect := image.Rect(x, y, x+srcRect.Dx(), y+srcRect.Dy()).Intersect(dstRect)
+			draw.Draw(dst, rect, src, srcRect.Min, draw.Over)
+		}
+	}
+}
+
+// IsScaled returns whether the clip is 9 slice scaled
+func (c *Clip) IsScaled() bool {
+	return c.scaled
+}
+
+// ResizeScaled draws the 9 slices of a scaled clip again at another size (in
+// unscaled pixels), other clips are only resized
+func (c *Clip) ResizeScaled(width, height int) {
+	if !c.scaled || c.width == width && c.height == height {
+		c.Resize(width, height)
+		return
+	}
+	c.mutex.Lock()
+	for i, f := range c.sprite.GetSlicedFrames() {
+		if i < len(c.frames) {
+			c.frames[i].Image = drawScaled(c.sprite, f, width, height)
+		}
+	}
+	c.frames[c.frame].Refresh()
+	c.mutex.Unlock()
+	c.resizeOverlay(width, height)
+	c.Resize(width, height)
+}
+
+// resizeOverlay creates the overlay image again at another size (in
+// unscaled pixels) and draws the highlight on it
+func (c *Clip) resizeOverlay(width, height int) {
+	highlight := c.highlight
+	c.highlight = nil
+	c.overlay.Image.Image = image.NewNRGBA(image.Rect(0, 0, width, height))
+	c.SetHighlight(highlight)
 }
 
 // GotoFrame goes to a frame of the clip
 func (c *Clip) GotoFrame(frame int, refresh bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.gotoFrame(frame, refresh)
+}
+
+func (c *Clip) gotoFrame(frame int, refresh bool) {
 	if c.frame != frame && frame >= 0 && frame < len(c.frames) {
 		c.frame = frame
 		dirty := false
@@ -177,13 +450,46 @@ func (c *Clip) GotoFrame(frame int, refresh bool) {
 			}
 		}
 		if dirty && refresh {
-			c.container.Refresh()
+			RefreshContainer(c.container)
 		}
 	}
 }
 
+// GetFrame gets the current frame of the clip
+func (c *Clip) GetFrame() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.frame
+}
+
+// GetFrameIndex gets the index of a named frame, or -1 if it does not exist
+func (c *Clip) GetFrameIndex(name string) int {
+	if i, ok := c.names[name]; ok {
+		return i
+	}
+	return -1
+}
+
+// GotoFrameByName goes to a named frame of the clip, it returns an error
+// when the clip has no frame with that name
+func (c *Clip) GotoFrameByName(name string, refresh bool) error {
+	i := c.GetFrameIndex(name)
+	if i < 0 {
+		return fmt.Errorf("clip '%s': frame '%s' not found", c.name, name)
+	}
+	c.GotoFrame(i, refresh)
+	return nil
+}
+
 // OnPress sets the mouse down handler
 func (c *Clip) OnPress(handler func(left, right, middle, alt, control bool)) {
+	c.OnPressEvent(func(ev *Event) {
+		handler(ev.Left, ev.Right, ev.Middle, ev.Alt, ev.Control)
+	})
+}
+
+// OnPressEvent sets the mouse down handler that gets the full event
+func (c *Clip) OnPressEvent(handler func(ev *Event)) {
 	c.onPress = handler
 	c.overlay.OnMouseDown(func(ev *desktop.MouseEvent) {
 		c.MouseDown(ev)
@@ -193,12 +499,19 @@ func (c *Clip) OnPress(handler func(left, right, middle, alt, control bool)) {
 // MouseDown handles the mouse down event
 func (c *Clip) MouseDown(ev *desktop.MouseEvent) {
 	if c.onPress != nil {
-		c.onPress(ev.Button&desktop.MouseButtonPrimary > 0, ev.Button&desktop.MouseButtonSecondary > 0, ev.Button&desktop.MouseButtonTertiary > 0, false, false)
+		c.onPress(newMouseEvent(ev))
 	}
 }
 
 // OnRelease sets the mouse up handler
 func (c *Clip) OnRelease(handler func(left, right, middle, alt, control bool)) {
+	c.OnReleaseEvent(func(ev *Event) {
+		handler(ev.Left, ev.Right, ev.Middle, ev.Alt, ev.Control)
+	})
+}
+
+// OnReleaseEvent sets the mouse up handler that gets the full event
+func (c *Clip) OnReleaseEvent(handler func(ev *Event)) {
 	c.onRelease = handler
 	c.overlay.OnMouseUp(func(ev *desktop.MouseEvent) {
 		c.MouseUp(ev)
@@ -208,22 +521,29 @@ func (c *Clip) OnRelease(handler func(left, right, middle, alt, control bool)) {
 // MouseUp handles the mouse up event
 func (c *Clip) MouseUp(ev *desktop.MouseEvent) {
 	if c.onRelease != nil {
-		c.onRelease(ev.Button&desktop.MouseButtonPrimary > 0, ev.Button&desktop.MouseButtonSecondary > 0, ev.Button&desktop.MouseButtonTertiary > 0, false, false)
+		c.onRelease(newMouseEvent(ev))
 	}
 }
 
 // OnEnter sets the enter handler
 func (c *Clip) OnEnter(handler func(left, right, middle, alt, control bool)) {
+	c.OnEnterEvent(func(ev *Event) {
+		handler(ev.Left, ev.Right, ev.Middle, ev.Alt, ev.Control)
+	})
+}
+
+// OnEnterEvent sets the enter handler that gets the full event
+func (c *Clip) OnEnterEvent(handler func(ev *Event)) {
 	c.onEnter = handler
 	c.overlay.OnMouseIn(func(ev *desktop.MouseEvent) {
 		c.MouseIn(ev)
 	})
 }
 
-// MouseUp handles the mouse up event
+// MouseIn handles the mouse in event
 func (c *Clip) MouseIn(ev *desktop.MouseEvent) {
 	if c.onEnter != nil {
-		c.onEnter(ev.Button&desktop.MouseButtonPrimary > 0, ev.Button&desktop.MouseButtonSecondary > 0, ev.Button&desktop.MouseButtonTertiary > 0, false, false)
+		c.onEnter(newMouseEvent(ev))
 	}
 }
 
@@ -244,6 +564,13 @@ func (c *Clip) MouseOut() {
 
 // OnOver sets the mouse moved handler
 func (c *Clip) OnOver(handler func(left, right, middle, alt, control bool)) {
+	c.OnOverEvent(func(ev *Event) {
+		handler(ev.Left, ev.Right, ev.Middle, ev.Alt, ev.Control)
+	})
+}
+
+// OnOverEvent sets the mouse moved handler that gets the full event
+func (c *Clip) OnOverEvent(handler func(ev *Event)) {
 	c.onOver = handler
 	c.overlay.OnMouseMoved(func(ev *desktop.MouseEvent) {
 		c.MouseMoved(ev)
@@ -253,6 +580,72 @@ func (c *Clip) OnOver(handler func(left, right, middle, alt, control bool)) {
 // MouseMoved handles the mouse moved event
 func (c *Clip) MouseMoved(ev *desktop.MouseEvent) {
 	if c.onOver != nil {
-		c.onOver(ev.Button&desktop.MouseButtonPrimary > 0, ev.Button&desktop.MouseButtonSecondary > 0, ev.Button&desktop.MouseButtonTertiary > 0, false, false)
+		c.onOver(newMouseEvent(ev))
+	}
+}
+
+// OnTap sets the tap handler (for touch screens)
+func (c *Clip) OnTap(handler func()) {
+	c.OnTapEvent(func(ev *Event) {
+		handler()
+	})
+}
+
+// OnTapEvent sets the tap handler that gets the full event
+func (c *Clip) OnTapEvent(handler func(ev *Event)) {
+	c.onTap = handler
+	c.overlay.OnTapped(func(ev *fyne.PointEvent) {
+		c.Tapped(ev)
+	})
+}
+
+// Tapped handles the tap event
+func (c *Clip) Tapped(ev *fyne.PointEvent) {
+	if c.onTap != nil {
+		c.onTap(newPointEvent(ev))
+	}
+}
+
+// OnTapSecondary sets the secondary tap handler (for touch screens)
+func (c *Clip) OnTapSecondary(handler func()) {
+	c.OnTapSecondaryEvent(func(ev *Event) {
+		handler()
+	})
+}
+
+// OnTapSecondaryEvent sets the secondary tap handler that gets the full event
+func (c *Clip) OnTapSecondaryEvent(handler func(ev *Event)) {
+	c.onTapSecondary = handler
+	c.overlay.OnTappedSecondary(func(ev *fyne.PointEvent) {
+		c.TappedSecondary(ev)
+	})
+}
+
+// TappedSecondary handles the secondary tap event
+func (c *Clip) TappedSecondary(ev *fyne.PointEvent) {
+	if c.onTapSecondary != nil {
+		c.onTapSecondary(newPointEvent(ev))
+	}
+}
+
+// OnLongPress sets the long press handler (for touch screens)
+func (c *Clip) OnLongPress(handler func()) {
+	c.OnLongPressEvent(func(ev *Event) {
+		handler()
+	})
+}
+
+// OnLongPressEvent sets the long press handler that gets the full event
+func (c *Clip) OnLongPressEvent(handler func(ev *Event)) {
+	c.onLongPress = handler
+	c.overlay.OnLongPress(func(ev *fyne.PointEvent) {
+		c.LongPressed(ev)
+	})
+}
+
+// LongPressed handles the long press event
+func (c *Clip) LongPressed(ev *fyne.PointEvent) {
+	if c.onLongPress != nil {
+		c.onLongPress(newPointEvent(ev))
 	}
 }
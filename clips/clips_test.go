@@ -0,0 +1,100 @@
+package clips
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/mevdschee/fyne-mines/sprites"
+)
+
+// newSlicedSprite creates a nine-slice sprite with slices of 1, 3 and 1
+// pixels and a frame per count, every pixel of the sheet has its own color
+func newSlicedSprite(count int, fill string) *sprites.Sprite {
+	img := image.NewNRGBA(image.Rect(0, 0, 5*count, 5))
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 5*count; x++ {
+			img.Set(x, y, slicedColor(x, y))
+		}
+	}
+	var src image.Image = img
+	return &sprites.Sprite{Image: &src, Name: "sliced", Widths: [3]int{1, 3, 1}, Heights: [3]int{1, 3, 1}, Count: count, Names: []string{"up", "down"}[:count], Fill: fill}
+}
+
+func slicedColor(x, y int) color.NRGBA {
+	return color.NRGBA{R: uint8(20 * x), G: uint8(40 * y), B: 100, A: 255}
+}
+
+// tiledSource gets the position on a slice of 1, 3 and 1 pixels that is
+// shown at a position of a tiled size
+func tiledSource(pos, size int) int {
+	switch {
+	case pos < 1:
+		return pos
+	case pos >= size-1:
+		return 4
+	}
+	return 1 + (pos-1)%3
+}
+
+// checkTiled checks that a frame of a sliced sprite is tiled over an image
+func checkTiled(t *testing.T, img image.Image, frame int) {
+	t.Helper()
+	bounds := img.Bounds()
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			want := slicedColor(5*frame+tiledSource(x, bounds.Dx()), tiledSource(y, bounds.Dy()))
+			if got := color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)); got != want {
+				t.Errorf("frame %d at %v: pixel at (%d,%d) is %v, want %v", frame, bounds.Size(), x, y, got, want)
+				return
+			}
+		}
+	}
+}
+
+// TestDrawTiled tiles the 3x3 centre of a sprite over sizes that are not a
+// multiple of it, the pattern is repeated up to the right and bottom edges
+func TestDrawTiled(t *testing.T) {
+	sprite := newSlicedSprite(1, sprites.FillTile)
+	for _, size := range []image.Point{{5, 5}, {10, 9}, {12, 6}, {3, 3}} {
+		checkTiled(t, drawScaled(sprite, sprite.GetSlicedFrames()[0], size.X, size.Y), 0)
+	}
+}
+
+// TestDrawStretched stretches the centre of a sprite, a pixel of the centre
+// is then shown larger instead of repeated
+func TestDrawStretched(t *testing.T) {
+	sprite := newSlicedSprite(1, sprites.FillStretch)
+	img := drawScaled(sprite, sprite.GetSlicedFrames()[0], 11, 11)
+	for x := 1; x < 4; x++ {
+		if got, want := img.At(x, 5), slicedColor(1, 2); got != want {
+			t.Errorf("stretched pixel at (%d,5) is %v, want %v", x, got, want)
+		}
+	}
+}
+
+// TestScaledFrames goes to the frames of a nine-slice clip with two frames,
+// the clip shows the slices of that frame, also after a resize
+func TestScaledFrames(t *testing.T) {
+	test.NewApp()
+	clip := NewScaled(newSlicedSprite(2, sprites.FillTile), "sliced", 2, 1, 10, 9, 1)
+	draw := func() image.Image {
+		dst := image.NewNRGBA(image.Rect(0, 0, 20, 20))
+		clip.Draw(dst)
+		return dst.SubImage(clip.GetBounds())
+	}
+	checkTiled(t, draw(), 0)
+	clip.GotoFrame(1, true)
+	if clip.GetFrame() != 1 || !clip.frames[1].Visible() || clip.frames[0].Visible() {
+		t.Errorf("clip is at frame %d after GotoFrame(1)", clip.GetFrame())
+	}
+	checkTiled(t, draw(), 1)
+	clip.ResizeScaled(13, 8)
+	checkTiled(t, draw(), 1)
+	err := clip.GotoFrameByName("up", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTiled(t, draw(), 0)
+}
@@ -0,0 +1,52 @@
+package clips
+
+import (
+	"image"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"golang.org/x/image/draw"
+)
+
+// GetBounds gets the rectangle of the clip (in unscaled pixels)
+func (c *Clip) GetBounds() image.Rectangle {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.bounds()
+}
+
+func (c *Clip) bounds() image.Rectangle {
+	return image.Rect(c.x, c.y, c.x+c.width, c.y+c.height)
+}
+
+// Draw draws the current frame of the clip (in unscaled pixels) without a GPU
+func (c *Clip) Draw(dst draw.Image) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	opacity := c.opacity * c.layerOpacity
+	if !c.container.Visible() || opacity <= 0 {
+		return
+	}
+	src := c.frames[c.frame].Image
+	if src == nil {
+		return
+	}
+	var mask image.Image
+	if opacity < 1 {
+		mask = image.NewUniform(color.Alpha16{A: uint16(opacity * 0xffff)})
+	}
+	if src.Bounds().Dx() == c.width && src.Bounds().Dy() == c.height {
+		draw.DrawMask(dst, c.bounds(), src, src.Bounds().Min, mask, image.Point{}, draw.Over)
+		return
+	}
+	draw.NearestNeighbor.Scale(dst, c.bounds(), src, src.Bounds(), draw.Over, &draw.Options{SrcMask: mask})
+}
+
+// RefreshContainer refreshes a container of a movie, without a running app
+// (as when a movie is only rendered) there is nothing to refresh
+func RefreshContainer(container *fyne.Container) {
+	if fyne.CurrentApp() == nil {
+		return
+	}
+	container.Refresh()
+}
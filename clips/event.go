@@ -0,0 +1,102 @@
+package clips
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// Event is a mouse, touch or key event on a clip
+type Event struct {
+	// Position is relative to the clip (in screen pixels)
+	Position fyne.Position
+	// AbsolutePosition is relative to the window (in screen pixels)
+	AbsolutePosition fyne.Position
+	// Left, Right and Middle are the mouse buttons that are down
+	Left, Right, Middle bool
+	// Shift, Control, Alt and Super are the modifier keys that are down
+	Shift, Control, Alt, Super bool
+	// Delta is the distance dragged or scrolled (in screen pixels)
+	Delta fyne.Delta
+	// Key is the name of the key that was typed, empty for a rune
+	Key fyne.KeyName
+	// Rune is the character that was typed, zero for a key
+	Rune rune
+	// Clip is the clip that had the focus when a key was typed or the clip
+	// of a bound action, nil otherwise
+	Clip *Clip
+	// Time is when the event was received
+	Time time.Time
+}
+
+// newMouseEvent creates an event from a desktop mouse event
+func newMouseEvent(ev *desktop.MouseEvent) *Event {
+	return &Event{
+		Position:         ev.Position,
+		AbsolutePosition: ev.AbsolutePosition,
+		Left:             ev.Button&desktop.MouseButtonPrimary > 0,
+		Right:            ev.Button&desktop.MouseButtonSecondary > 0,
+		Middle:           ev.Button&desktop.MouseButtonTertiary > 0,
+		Shift:            ev.Modifier&fyne.KeyModifierShift > 0,
+		Control:          ev.Modifier&fyne.KeyModifierControl > 0,
+		Alt:              ev.Modifier&fyne.KeyModifierAlt > 0,
+		Super:            ev.Modifier&fyne.KeyModifierSuper > 0,
+		Time:             time.Now(),
+	}
+}
+
+// newPointEvent creates an event from a tap
+func newPointEvent(ev *fyne.PointEvent) *Event {
+	return &Event{
+		Position:         ev.Position,
+		AbsolutePosition: ev.AbsolutePosition,
+		Time:             time.Now(),
+	}
+}
+
+// newDragEvent creates an event from a drag
+func newDragEvent(ev *fyne.DragEvent) *Event {
+	e := newPointEvent(&ev.PointEvent)
+	e.Delta = ev.Dragged
+	return e
+}
+
+// newScrollEvent creates an event from a scroll, with the modifier keys that
+// are down when the driver knows them
+func newScrollEvent(ev *fyne.ScrollEvent) *Event {
+	e := newPointEvent(&ev.PointEvent)
+	e.Delta = ev.Scrolled
+	e.setModifiers()
+	return e
+}
+
+// NewKeyEvent creates an event from a typed key, with the modifier keys that
+// are down when the driver knows them
+func NewKeyEvent(ev *fyne.KeyEvent) *Event {
+	e := &Event{Key: ev.Name, Time: time.Now()}
+	e.setModifiers()
+	return e
+}
+
+// NewRuneEvent creates an event from a typed rune
+func NewRuneEvent(r rune) *Event {
+	e := &Event{Rune: r, Time: time.Now()}
+	e.setModifiers()
+	return e
+}
+
+// setModifiers sets the modifier keys that are down when the driver knows them
+func (e *Event) setModifiers() {
+	app := fyne.CurrentApp()
+	if app == nil {
+		return
+	}
+	if driver, ok := app.Driver().(desktop.Driver); ok {
+		modifier := driver.CurrentKeyModifiers()
+		e.Shift = modifier&fyne.KeyModifierShift > 0
+		e.Control = modifier&fyne.KeyModifierControl > 0
+		e.Alt = modifier&fyne.KeyModifierAlt > 0
+		e.Super = modifier&fyne.KeyModifierSuper > 0
+	}
+}
@@ -0,0 +1,91 @@
+package clips
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/mevdschee/fyne-mines/interactive"
+)
+
+// KeyHandler handles the key events that bubble up from a focused clip, it
+// returns whether the event was handled
+type KeyHandler interface {
+	HandleKey(ev *Event) bool
+}
+
+// SetParent sets where the key events go that the clip does not handle
+func (c *Clip) SetParent(parent KeyHandler) {
+	c.parent = parent
+}
+
+// OnKey sets the key handler of the clip, it returns whether it handled the
+// event, if not the event goes to the parent of the clip, the clip only gets
+// key events when it is focusable
+func (c *Clip) OnKey(handler func(ev *Event) bool) {
+	c.onKey = handler
+}
+
+// SetFocusable sets whether the clip can get the keyboard focus, with tab or
+// with Focus, a press on the clip does not give it the focus
+func (c *Clip) SetFocusable(focusable bool) {
+	if focusable == (c.focusArea != nil) {
+		return
+	}
+	if !focusable {
+		c.container.Remove(c.focusArea)
+		c.focusArea = nil
+		return
+	}
+	c.focusArea = interactive.NewFocusArea()
+	c.focusArea.OnTypedKey(func(ev *fyne.KeyEvent) {
+		c.HandleKey(NewKeyEvent(ev))
+	})
+	c.focusArea.OnTypedRune(func(r rune) {
+		c.HandleKey(NewRuneEvent(r))
+	})
+	c.focusArea.OnFocusChanged(func(focused bool) {
+		if c.onFocus != nil {
+			c.onFocus(focused)
+		}
+	})
+	c.addBelowOverlay(c.focusArea)
+}
+
+// HandleKey handles a key event on the clip and passes it to the parent
+// when the clip does not handle it
+func (c *Clip) HandleKey(ev *Event) bool {
+	if ev.Clip == nil {
+		ev.Clip = c
+	}
+	if c.onKey != nil && c.onKey(ev) {
+		return true
+	}
+	if c.parent != nil {
+		return c.parent.HandleKey(ev)
+	}
+	return false
+}
+
+// OnFocus sets the handler that is called when the clip gains or loses focus
+func (c *Clip) OnFocus(handler func(focused bool)) {
+	c.onFocus = handler
+}
+
+// IsFocusable returns whether the clip can have the keyboard focus
+func (c *Clip) IsFocusable() bool {
+	return c.focusArea != nil
+}
+
+// IsFocused returns whether the clip has the keyboard focus
+func (c *Clip) IsFocused() bool {
+	return c.focusArea != nil && c.focusArea.IsFocused()
+}
+
+// Focus gives the clip the keyboard focus, if it is focusable and shown
+func (c *Clip) Focus() {
+	if c.focusArea == nil || fyne.CurrentApp() == nil {
+		return
+	}
+	canvas := fyne.CurrentApp().Driver().CanvasForObject(c.focusArea)
+	if canvas != nil {
+		canvas.Focus(c.focusArea)
+	}
+}
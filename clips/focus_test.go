@@ -0,0 +1,43 @@
+package clips
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestFocusable(t *testing.T) {
+	test.NewApp()
+	clip := New(newTestSprite(2), "clip", 0, 0, 1)
+	keys := 0
+	clip.OnKey(func(ev *Event) bool {
+		keys++
+		return true
+	})
+	if clip.IsFocusable() {
+		t.Error("clip with a key handler is focusable")
+	}
+	w := test.NewWindow(clip.GetContainer())
+	defer w.Close()
+	clip.MouseDown(&desktop.MouseEvent{Button: desktop.MouseButtonPrimary})
+	clip.SetFocusable(true)
+	clip.MouseDown(&desktop.MouseEvent{Button: desktop.MouseButtonPrimary})
+	if clip.IsFocused() {
+		t.Error("clip is focused by a press")
+	}
+	clip.Focus()
+	if !clip.IsFocused() {
+		t.Fatal("focusable clip is not focused")
+	}
+	test.Type(w.Canvas().Focused(), "f")
+	w.Canvas().Focused().TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	if keys != 2 {
+		t.Errorf("handled %d keys, want 2", keys)
+	}
+	clip.SetFocusable(false)
+	if clip.IsFocusable() || clip.IsFocused() {
+		t.Error("clip is still focusable")
+	}
+}
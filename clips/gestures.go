@@ -0,0 +1,106 @@
+package clips
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/mevdschee/fyne-mines/interactive"
+)
+
+// OnDrag sets the drag handler, the distance dragged is in the Delta of the
+// event, the mouse events keep going to the clip under the pointer
+func (c *Clip) OnDrag(handler func(ev *Event)) {
+	c.onDrag = handler
+	c.addDragArea()
+	c.dragArea.OnDragged(func(ev *fyne.DragEvent) {
+		c.Dragged(ev)
+	})
+}
+
+// Dragged handles the drag event
+func (c *Clip) Dragged(ev *fyne.DragEvent) {
+	if c.onDrag != nil {
+		c.onDrag(newDragEvent(ev))
+	}
+}
+
+// OnDragEnd sets the drag end handler
+func (c *Clip) OnDragEnd(handler func()) {
+	c.onDragEnd = handler
+	c.addDragArea()
+	c.dragArea.OnDragEnd(func() {
+		c.DragEnd()
+	})
+}
+
+// DragEnd handles the drag end event
+func (c *Clip) DragEnd() {
+	if c.onDragEnd != nil {
+		c.onDragEnd()
+	}
+}
+
+// OnScroll sets the scroll wheel handler, the distance scrolled is in the
+// Delta of the event, the containers of the clip no longer scroll over it
+func (c *Clip) OnScroll(handler func(ev *Event)) {
+	c.onScroll = handler
+	if c.scrollArea == nil {
+		c.scrollArea = interactive.NewScrollArea()
+		c.addBelowOverlay(c.scrollArea)
+	}
+	c.scrollArea.OnScrolled(func(ev *fyne.ScrollEvent) {
+		c.Scrolled(ev)
+	})
+}
+
+// Scrolled handles the scroll event
+func (c *Clip) Scrolled(ev *fyne.ScrollEvent) {
+	if c.onScroll != nil {
+		c.onScroll(newScrollEvent(ev))
+	}
+}
+
+// OnDoubleClick sets the double click (or double tap) handler, the taps on
+// the clip are then delayed until it is clear no second tap follows
+func (c *Clip) OnDoubleClick(handler func(ev *Event)) {
+	c.onDoubleClick = handler
+	if c.doubleTap == nil {
+		c.doubleTap = interactive.NewDoubleTapImage(c.overlay)
+		for i, o := range c.container.Objects {
+			if o == fyne.CanvasObject(c.overlay) {
+				c.container.Objects[i] = c.doubleTap
+			}
+		}
+		RefreshContainer(c.container)
+	}
+	c.overlay.OnDoubleTapped(func(ev *fyne.PointEvent) {
+		c.DoubleTapped(ev)
+	})
+}
+
+// DoubleTapped handles the double tap event
+func (c *Clip) DoubleTapped(ev *fyne.PointEvent) {
+	if c.onDoubleClick != nil {
+		c.onDoubleClick(newPointEvent(ev))
+	}
+}
+
+// addDragArea adds the object that receives the drags of the clip
+func (c *Clip) addDragArea() {
+	if c.dragArea == nil {
+		c.dragArea = interactive.NewDragArea()
+		c.addBelowOverlay(c.dragArea)
+	}
+}
+
+// addBelowOverlay adds an object to the clip right below the overlay that
+// receives the mouse events
+func (c *Clip) addBelowOverlay(object fyne.CanvasObject) {
+	objects := []fyne.CanvasObject{}
+	for _, o := range c.container.Objects {
+		if o == fyne.CanvasObject(c.overlay) || (c.doubleTap != nil && o == fyne.CanvasObject(c.doubleTap)) {
+			objects = append(objects, object)
+		}
+		objects = append(objects, o)
+	}
+	c.container.Objects = objects
+	RefreshContainer(c.container)
+}
@@ -0,0 +1,79 @@
+package clips
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestDrag(t *testing.T) {
+	test.NewApp()
+	clip := New(newTestSprite(1), "clip", 0, 0, 4)
+	var deltas []fyne.Delta
+	ends := 0
+	clip.OnDrag(func(ev *Event) {
+		deltas = append(deltas, ev.Delta)
+	})
+	clip.OnDragEnd(func() {
+		ends++
+	})
+	w := test.NewWindow(clip.GetContainer())
+	defer w.Close()
+	w.Resize(fyne.NewSize(16, 16))
+	test.Drag(w.Canvas(), fyne.NewPos(8, 8), 3, -2)
+	test.Drag(w.Canvas(), fyne.NewPos(8, 8), -1, 5)
+	if len(deltas) != 2 || deltas[0] != (fyne.Delta{DX: 3, DY: -2}) || deltas[1] != (fyne.Delta{DX: -1, DY: 5}) {
+		t.Errorf("dragged %v, want [{3 -2} {-1 5}]", deltas)
+	}
+	if ends != 2 {
+		t.Errorf("%d drags ended, want 2", ends)
+	}
+	// the overlay still receives the mouse events above the drag area
+	objects := clip.GetContainer().Objects
+	if objects[len(objects)-1] != fyne.CanvasObject(clip.overlay) {
+		t.Error("overlay is not on top of the clip")
+	}
+}
+
+func TestScroll(t *testing.T) {
+	test.NewApp()
+	clip := New(newTestSprite(1), "clip", 0, 0, 4)
+	var deltas []fyne.Delta
+	clip.OnScroll(func(ev *Event) {
+		deltas = append(deltas, ev.Delta)
+	})
+	w := test.NewWindow(clip.GetContainer())
+	defer w.Close()
+	w.Resize(fyne.NewSize(16, 16))
+	test.Scroll(w.Canvas(), fyne.NewPos(8, 8), 0, -10)
+	if len(deltas) != 1 || deltas[0] != (fyne.Delta{DX: 0, DY: -10}) {
+		t.Errorf("scrolled %v, want [{0 -10}]", deltas)
+	}
+}
+
+func TestDoubleClick(t *testing.T) {
+	test.NewApp()
+	clip := New(newTestSprite(1), "clip", 0, 0, 4)
+	clicks := 0
+	clip.OnScroll(func(ev *Event) {})
+	clip.OnDoubleClick(func(ev *Event) {
+		clicks++
+	})
+	// a second handler replaces the first and keeps the overlay in place
+	clip.OnDoubleClick(func(ev *Event) {
+		clicks += 10
+	})
+	w := test.NewWindow(clip.GetContainer())
+	defer w.Close()
+	w.Resize(fyne.NewSize(16, 16))
+	objects := clip.GetContainer().Objects
+	top, ok := objects[len(objects)-1].(fyne.DoubleTappable)
+	if !ok || objects[len(objects)-1] != fyne.CanvasObject(clip.doubleTap) {
+		t.Fatal("double tap overlay is not on top of the clip")
+	}
+	test.DoubleTap(top)
+	if clicks != 10 {
+		t.Errorf("double click handlers counted %d, want 10", clicks)
+	}
+}
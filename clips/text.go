@@ -0,0 +1,274 @@
+package clips
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"github.com/mevdschee/fyne-mines/interactive"
+	"github.com/mevdschee/fyne-mines/sprites"
+	"golang.org/x/image/draw"
+)
+
+// TypeText is the type of a clip that shows a line of text, it is drawn
+// with the frames of its sprite as glyphs or with a font
+const TypeText = "text"
+
+// glyphNames are the frame names of the glyphs of characters that can not
+// be a frame name by themselves
+var glyphNames = map[string]rune{"minus": '-', "space": ' ', "dot": '.', "colon": ':', "slash": '/'}
+
+// text is the line of text of a clip, a clip without a width fits its text
+// and is then aligned to the x it was placed at
+type text struct {
+	value      string
+	align      fyne.TextAlign
+	fit        bool
+	anchor     int
+	glyphs     map[rune]*image.NRGBA
+	glyphWidth int
+	label      *canvas.Text
+	size       int
+	color      color.Color
+}
+
+// NewBitmapText creates a new text clip that draws the frames of a sprite
+// as glyphs, frames are found by their name (such as "0" or "A") or by the
+// names "minus", "space", "dot", "colon" and "slash", the width may be 0
+func NewBitmapText(sprite *sprites.Sprite, name string, x, y, width int, value string, align fyne.TextAlign, scale int) *Clip {
+	t := &text{
+		align:      align,
+		fit:        width == 0,
+		anchor:     x,
+		glyphs:     map[rune]*image.NRGBA{},
+		glyphWidth: sprite.Width,
+	}
+	for _, f := range sprite.GetFrames() {
+		r, ok := glyphNames[f.Name]
+		if !ok {
+			if utf8.RuneCountInString(f.Name) != 1 {
+				continue
+			}
+			r, _ = utf8.DecodeRuneInString(f.Name)
+		}
+		glyph := image.NewNRGBA(image.Rect(0, 0, sprite.Width, sprite.Height))
+		drawFrame(glyph, *sprite.Image, f)
+		t.glyphs[r] = glyph
+	}
+	frame0 := canvas.NewImageFromImage(image.NewNRGBA(image.Rect(0, 0, width, sprite.Height)))
+	frame0.ScaleMode = canvas.ImageScalePixels
+	clip := newTextClip(name, x, y, width, sprite.Height, scale, frame0, t)
+	clip.sprite = sprite
+	clip.container.Add(frame0)
+	clip.container.Add(clip.overlay)
+	clip.SetText(value)
+	return clip
+}
+
+// NewText creates a new text clip that draws its text with the font of the
+// theme in a size and a style (in unscaled pixels), the width may be 0, the
+// text is not drawn when the movie is rendered without a GPU
+func NewText(name string, x, y, width int, value string, size int, col color.Color, style fyne.TextStyle, align fyne.TextAlign, scale int) *Clip {
+	label := canvas.NewText(value, col)
+	label.TextSize = float32(size * scale)
+	label.TextStyle = style
+	label.Alignment = align
+	t := &text{
+		align:  align,
+		fit:    width == 0,
+		anchor: x,
+		label:  label,
+		size:   size,
+		color:  col,
+	}
+	height := int(math.Ceil(float64(fyne.MeasureText("M", float32(size), style).Height)))
+	frame0 := canvas.NewImageFromImage(image.NewNRGBA(image.Rect(0, 0, 1, 1)))
+	clip := newTextClip(name, x, y, width, height, scale, frame0, t)
+	clip.container.Add(frame0)
+	clip.container.Add(label)
+	clip.container.Add(clip.overlay)
+	clip.SetText(value)
+	return clip
+}
+
+func newTextClip(name string, x, y, width, height, scale int, frame0 *canvas.Image, t *text) *Clip {
+	return &Clip{
+		container:    container.NewStack(),
+		name:         name,
+		x:            x,
+		y:            y,
+		width:        width,
+		height:       height,
+		scale:        scale,
+		opacity:      1,
+		layerOpacity: 1,
+		overlay:      interactive.NewImage(canvas.NewImageFromImage(image.NewNRGBA(image.Rect(0, 0, width, height)))),
+		frame:        0,
+		frames:       []*canvas.Image{frame0},
+		names:        map[string]int{},
+		durations:    []time.Duration{time.Second / defaultFrameRate},
+		text:         t,
+	}
+}
+
+// IsText returns whether the clip is a text clip
+func (c *Clip) IsText() bool {
+	return c.text != nil
+}
+
+// FitsText returns whether a text clip has a width (0 when it fits its text)
+// and a font size (0 when it draws glyphs)
+func (c *Clip) FitsText(width, size int) bool {
+	if c.text == nil || c.text.size != size {
+		return false
+	}
+	if c.text.fit {
+		return width == 0
+	}
+	return c.width == width
+}
+
+// GetText gets the text of a text clip
+func (c *Clip) GetText() string {
+	if c.text == nil {
+		return ""
+	}
+	return c.text.value
+}
+
+// SetText sets the text of a text clip, a clip that fits its text is
+// resized and aligned again
+func (c *Clip) SetText(value string) {
+	t := c.text
+	if t == nil {
+		return
+	}
+	c.mutex.Lock()
+	t.value = value
+	oldWidth, height, anchor, y := c.width, c.height, t.anchor, c.y
+	width := oldWidth
+	if t.fit {
+		width = t.measure(value)
+	}
+	if t.label != nil {
+		t.label.Text = value
+		t.label.Refresh()
+	} else {
+		c.frames[0].Image = t.draw(value, width, height)
+		c.frames[0].Refresh()
+	}
+	c.mutex.Unlock()
+	if width != oldWidth {
+		c.resizeOverlay(width, height)
+		c.Resize(width, height)
+	}
+	if t.fit {
+		c.Move(anchor, y)
+	}
+}
+
+// measure gets the width of a text (in unscaled pixels)
+func (t *text) measure(value string) int {
+	if t.label == nil {
+		return utf8.RuneCountInString(value) * t.glyphWidth
+	}
+	size := fyne.MeasureText(value, float32(t.size), t.label.TextStyle)
+	return int(math.Ceil(float64(size.Width)))
+}
+
+// left gets the x of a clip that is aligned to its anchor
+func (t *text) left(width int) int {
+	switch t.align {
+	case fyne.TextAlignCenter:
+		return t.anchor - width/2
+	case fyne.TextAlignTrailing:
+		return t.anchor - width
+	}
+	return t.anchor
+}
+
+// draw draws the glyphs of a text aligned in an image, characters without
+// a glyph are left empty
+func (t *text) draw(value string, width, height int) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	x := 0
+	switch t.align {
+	case fyne.TextAlignCenter:
+		x = (width - t.measure(value)) / 2
+	case fyne.TextAlignTrailing:
+		x = width - t.measure(value)
+	}
+	for _, r := range value {
+		if glyph, ok := t.glyphs[r]; ok {
+			draw.Copy(dst, image.Point{x, 0}, glyph, glyph.Bounds(), draw.Over, nil)
+		}
+		x += t.glyphWidth
+	}
+	return dst
+}
+
+// applyOpacity fades the color of a text in a font
+func (t *text) applyOpacity(opacity float64) {
+	if t.label == nil {
+		return
+	}
+	col := color.NRGBAModel.Convert(t.color).(color.NRGBA)
+	col.A = uint8(float64(col.A) * opacity)
+	t.label.Color = col
+	t.label.Refresh()
+}
+
+// ParseAlign reads an alignment of text from JSON, "left", "center" or
+// "right", empty is left
+func ParseAlign(align string) (fyne.TextAlign, error) {
+	switch align {
+	case "", "left":
+		return fyne.TextAlignLeading, nil
+	case "center":
+		return fyne.TextAlignCenter, nil
+	case "right":
+		return fyne.TextAlignTrailing, nil
+	}
+	return fyne.TextAlignLeading, fmt.Errorf("unknown alignment '%s'", align)
+}
+
+// ParseFont reads the style of a font from JSON, "regular", "bold",
+// "italic" or "monospace"
+func ParseFont(font string) (fyne.TextStyle, error) {
+	switch font {
+	case "regular":
+		return fyne.TextStyle{}, nil
+	case "bold":
+		return fyne.TextStyle{Bold: true}, nil
+	case "italic":
+		return fyne.TextStyle{Italic: true}, nil
+	case "monospace":
+		return fyne.TextStyle{Monospace: true}, nil
+	}
+	return fyne.TextStyle{}, fmt.Errorf("unknown font '%s'", font)
+}
+
+// ParseColor reads a color from JSON as "#rrggbb" or "#rrggbbaa", empty is
+// black
+func ParseColor(col string) (color.Color, error) {
+	if col == "" {
+		return color.Black, nil
+	}
+	hex := strings.TrimPrefix(col, "#")
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 8 || !strings.HasPrefix(col, "#") {
+		return nil, fmt.Errorf("invalid color '%s'", col)
+	}
+	return color.NRGBA{R: uint8(value >> 24), G: uint8(value >> 16), B: uint8(value >> 8), A: uint8(value)}, nil
+}
@@ -0,0 +1,158 @@
+package clips
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"os"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/mevdschee/fyne-mines/sprites"
+)
+
+// readDigits reads the sprite of the digits of the skin of the game
+func readDigits(t *testing.T) *sprites.Sprite {
+	imageData, err := os.ReadFile("../winxpskin.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta, err := os.ReadFile("../skin/sprites.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spriteMap, err := sprites.NewSpriteMap(imageData, string(meta))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return spriteMap["digits"]
+}
+
+// checkGlyphs checks that the image of a bitmap text clip shows the frames
+// of the sprite by name at the given x positions and is empty elsewhere,
+// an empty name is a character without a glyph
+func checkGlyphs(t *testing.T, clip *Clip, glyphs map[int]string) {
+	t.Helper()
+	img := clip.frames[0].Image
+	want := image.NewNRGBA(img.Bounds())
+	for x, name := range glyphs {
+		for i, f := range clip.sprite.GetFrames() {
+			if f.Name == name {
+				glyph := image.NewNRGBA(image.Rect(0, 0, clip.sprite.Width, clip.sprite.Height))
+				drawFrame(glyph, *clip.sprite.Image, clip.sprite.GetFrames()[i])
+				for gy := 0; gy < clip.sprite.Height; gy++ {
+					for gx := 0; gx < clip.sprite.Width; gx++ {
+						want.Set(x+gx, gy, glyph.At(gx, gy))
+					}
+				}
+			}
+		}
+	}
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if color.NRGBAModel.Convert(img.At(x, y)) != want.At(x, y) {
+				t.Errorf("text '%s' differs from the glyphs %v at (%d,%d)", clip.GetText(), glyphs, x, y)
+				return
+			}
+		}
+	}
+}
+
+func TestBitmapText(t *testing.T) {
+	test.NewApp()
+	digits := readDigits(t)
+	clip := NewBitmapText(digits, "text", 10, 5, 0, "1-2", fyne.TextAlignLeading, 1)
+	if bounds := clip.GetBounds(); bounds != image.Rect(10, 5, 43, 26) {
+		t.Errorf("text '1-2' at %v, want (10,5)-(43,26)", bounds)
+	}
+	checkGlyphs(t, clip, map[int]string{0: "1", 11: "minus", 22: "2"})
+	// characters without a glyph take the room of one
+	clip.SetText("1x 2")
+	if bounds := clip.GetBounds(); bounds != image.Rect(10, 5, 54, 26) {
+		t.Errorf("text '1x 2' at %v, want (10,5)-(54,26)", bounds)
+	}
+	checkGlyphs(t, clip, map[int]string{0: "1", 33: "2"})
+}
+
+func TestBitmapTextAlign(t *testing.T) {
+	test.NewApp()
+	digits := readDigits(t)
+	tests := []struct {
+		align fyne.TextAlign
+		x     int
+		left  int
+	}{
+		{fyne.TextAlignLeading, 0, 50},
+		{fyne.TextAlignCenter, 16, 39},
+		{fyne.TextAlignTrailing, 33, 28},
+	}
+	for _, tt := range tests {
+		// a text in a clip with a width is aligned within the clip
+		clip := NewBitmapText(digits, "text", 50, 0, 55, "12", tt.align, 1)
+		if bounds := clip.GetBounds(); bounds != image.Rect(50, 0, 105, 21) {
+			t.Errorf("align %d: clip with a width at %v, want (50,0)-(105,21)", tt.align, bounds)
+		}
+		checkGlyphs(t, clip, map[int]string{tt.x: "1", tt.x + 11: "2"})
+		// a clip that fits its text is aligned to its x
+		clip = NewBitmapText(digits, "text", 50, 0, 0, "12", tt.align, 1)
+		if bounds := clip.GetBounds(); bounds != image.Rect(tt.left, 0, tt.left+22, 21) {
+			t.Errorf("align %d: clip that fits its text at %v, want x %d", tt.align, bounds, tt.left)
+		}
+		checkGlyphs(t, clip, map[int]string{0: "1", 11: "2"})
+	}
+}
+
+func TestBitmapTextSetText(t *testing.T) {
+	test.NewApp()
+	digits := readDigits(t)
+	clip := NewBitmapText(digits, "text", 50, 0, 0, "1", fyne.TextAlignTrailing, 2)
+	clip.SetText("123")
+	if bounds := clip.GetBounds(); bounds != image.Rect(17, 0, 50, 21) {
+		t.Errorf("right aligned text at %v after SetText, want (17,0)-(50,21)", bounds)
+	}
+	if size := clip.GetContainer().Size(); size != fyne.NewSize(66, 42) {
+		t.Errorf("container of %v after SetText, want 66x42", size)
+	}
+	if bounds := clip.overlay.Image.Image.Bounds(); bounds.Dx() != 33 {
+		t.Errorf("overlay of %v after SetText, want a width of 33", bounds)
+	}
+	checkGlyphs(t, clip, map[int]string{0: "1", 11: "2", 22: "3"})
+	// a clip with a width keeps its size
+	clip = NewBitmapText(digits, "text", 0, 0, 33, "1", fyne.TextAlignTrailing, 1)
+	clip.SetText("12")
+	if bounds := clip.GetBounds(); bounds != image.Rect(0, 0, 33, 21) {
+		t.Errorf("text with a width at %v after SetText, want (0,0)-(33,21)", bounds)
+	}
+	checkGlyphs(t, clip, map[int]string{11: "1", 22: "2"})
+}
+
+func TestText(t *testing.T) {
+	test.NewApp()
+	red := color.NRGBA{R: 255, A: 255}
+	style := fyne.TextStyle{Bold: true}
+	clip := NewText("text", 100, 10, 0, "Hi", 12, red, style, fyne.TextAlignCenter, 2)
+	label := clip.text.label
+	if label.Text != "Hi" || label.TextSize != 24 || label.Color != red || label.TextStyle != style {
+		t.Errorf("label is '%s' of size %v in %v", label.Text, label.TextSize, label.Color)
+	}
+	clip.SetText("Hello")
+	width := int(math.Ceil(float64(fyne.MeasureText("Hello", 12, style).Width)))
+	if bounds := clip.GetBounds(); bounds.Min.X != 100-width/2 || bounds.Dx() != width {
+		t.Errorf("centered text 'Hello' at %v, want a width of %d around 100", bounds, width)
+	}
+	if label.Text != "Hello" {
+		t.Errorf("label is '%s' after SetText, want 'Hello'", label.Text)
+	}
+	clip.SetOpacity(0.5)
+	if col := label.Color.(color.NRGBA); col.A != 127 {
+		t.Errorf("label has alpha %d at half opacity, want 127", col.A)
+	}
+	// a text with a width keeps its size
+	clip = NewText("text", 0, 0, 40, "Hi", 12, red, style, fyne.TextAlignLeading, 1)
+	clip.SetText("Hello world")
+	if bounds := clip.GetBounds(); bounds.Dx() != 40 {
+		t.Errorf("text with a width at %v after SetText, want a width of 40", bounds)
+	}
+}
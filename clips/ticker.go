@@ -0,0 +1,77 @@
+package clips
+
+import (
+	"sync"
+	"time"
+)
+
+// tickRate is the interval at which the shared ticker runs
+const tickRate = time.Second / 60
+
+// tickable is anything that is driven by the shared ticker, it returns
+// false when it no longer needs to be ticked
+type tickable interface {
+	tick(elapsed time.Duration) bool
+}
+
+// ticker drives all playing clips from a single goroutine that only runs
+// while there is something to tick, a manual ticker does not start the
+// goroutine and is stepped by hand instead
+var ticker = struct {
+	sync.Mutex
+	items   map[tickable]bool
+	running bool
+	manual  bool
+	done    sync.WaitGroup
+}{items: map[tickable]bool{}}
+
+func startTicking(item tickable) {
+	ticker.Lock()
+	defer ticker.Unlock()
+	ticker.items[item] = true
+	if !ticker.running && !ticker.manual {
+		ticker.running = true
+		ticker.done.Add(1)
+		go tick()
+	}
+}
+
+func stopTicking(item tickable) {
+	ticker.Lock()
+	defer ticker.Unlock()
+	delete(ticker.items, item)
+}
+
+func tick() {
+	defer ticker.done.Done()
+	t := time.NewTicker(tickRate)
+	defer t.Stop()
+	last := time.Now()
+	for now := range t.C {
+		elapsed := now.Sub(last)
+		last = now
+		step(elapsed)
+		ticker.Lock()
+		if len(ticker.items) == 0 || ticker.manual {
+			ticker.running = false
+			ticker.Unlock()
+			return
+		}
+		ticker.Unlock()
+	}
+}
+
+// step ticks every item once and stops ticking the items that are done
+func step(elapsed time.Duration) {
+	ticker.Lock()
+	items := make([]tickable, 0, len(ticker.items))
+	for item := range ticker.items {
+		items = append(items, item)
+	}
+	ticker.Unlock()
+	for _, item := range items {
+		if !item.tick(elapsed) {
+			stopTicking(item)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package clips
+
+import (
+	"fmt"
+	"image"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"github.com/mevdschee/fyne-mines/interactive"
+	"github.com/mevdschee/fyne-mines/sprites"
+	"golang.org/x/image/draw"
+)
+
+// tilemapChunk is the number of columns and rows of tiles of a chunk, the
+// buffer is shown as an image per chunk so that a refresh only updates the
+// chunks that have changed
+const tilemapChunk = 8
+
+// tilemap is a grid of sprite frames that is drawn into a single image
+type tilemap struct {
+	columns, rows int
+	tileWidth     int
+	tileHeight    int
+	frames        []*image.NRGBA
+	buffer        *image.NRGBA
+	tiles         []int
+	chunks        []*canvas.Image
+	rects         []image.Rectangle
+	dirty         image.Rectangle
+}
+
+// NewTilemap creates a new clip that draws a grid of sprite frames into a
+// single image, all tiles start at the first frame
+func NewTilemap(sprite *sprites.Sprite, name string, x, y, columns, rows, scale int) *Clip {
+	t := &tilemap{
+		columns:    columns,
+		rows:       rows,
+		tileWidth:  sprite.Width,
+		tileHeight: sprite.Height,
+		frames:     []*image.NRGBA{},
+		buffer:     image.NewNRGBA(image.Rect(0, 0, columns*sprite.Width, rows*sprite.Height)),
+		tiles:      make([]int, columns*rows),
+	}
+	names := map[string]int{}
+	for i, f := range sprite.GetFrames() {
+		if f.Name != "" {
+			names[f.Name] = i
+		}
+		frame := image.NewNRGBA(image.Rect(0, 0, sprite.Width, sprite.Height))
+		drawFrame(frame, *sprite.Image, f)
+		t.frames = append(t.frames, frame)
+	}
+	for i := range t.tiles {
+		t.draw(i)
+	}
+	t.dirty = image.Rectangle{}
+	chunkWidth, chunkHeight := tilemapChunk*sprite.Width, tilemapChunk*sprite.Height
+	for y := 0; y < t.buffer.Bounds().Dy(); y += chunkHeight {
+		for x := 0; x < t.buffer.Bounds().Dx(); x += chunkWidth {
+			rect := image.Rect(x, y, x+chunkWidth, y+chunkHeight).Intersect(t.buffer.Bounds())
+			chunk := canvas.NewImageFromImage(t.buffer.SubImage(rect))
+			chunk.ScaleMode = canvas.ImageScalePixels
+			t.chunks = append(t.chunks, chunk)
+			t.rects = append(t.rects, rect)
+		}
+	}
+	objects := []fyne.CanvasObject{}
+	for _, chunk := range t.chunks {
+		objects = append(objects, chunk)
+	}
+	// the whole buffer is the frame of the clip, it is drawn without a GPU
+	// and the chunks are shown
+	frame0 := canvas.NewImageFromImage(t.buffer)
+	frame0.ScaleMode = canvas.ImageScalePixels
+	overlay := image.NewNRGBA(t.buffer.Bounds())
+	clip := &Clip{
+		container:    container.NewStack(),
+		name:         name,
+		sprite:       sprite,
+		x:            x,
+		y:            y,
+		width:        t.buffer.Bounds().Dx(),
+		height:       t.buffer.Bounds().Dy(),
+		scale:        scale,
+		opacity:      1,
+		layerOpacity: 1,
+		overlay:      interactive.NewImage(canvas.NewImageFromImage(overlay)),
+		frame:        0,
+		frames:       []*canvas.Image{frame0},
+		names:        names,
+		durations:    []time.Duration{time.Second / defaultFrameRate},
+		tilemap:      t,
+	}
+	clip.container.Add(container.New(t, objects...))
+	clip.container.Add(clip.overlay)
+	return clip
+}
+
+// Layout places the chunks of a tilemap on the part of the buffer they show
+func (t *tilemap) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	bounds := t.buffer.Bounds()
+	scaleX, scaleY := size.Width/float32(bounds.Dx()), size.Height/float32(bounds.Dy())
+	for i, o := range objects {
+		rect := t.rects[i]
+		o.Move(fyne.NewPos(float32(rect.Min.X)*scaleX, float32(rect.Min.Y)*scaleY))
+		o.Resize(fyne.NewSize(float32(rect.Dx())*scaleX, float32(rect.Dy())*scaleY))
+	}
+}
+
+// MinSize is zero as the clip sets the size of a tilemap
+func (t *tilemap) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
+// draw draws a tile into the buffer and marks its rectangle as dirty
+func (t *tilemap) draw(i int) {
+	x, y := (i%t.columns)*t.tileWidth, (i/t.columns)*t.tileHeight
+	rect := image.Rect(x, y, x+t.tileWidth, y+t.tileHeight)
+	if t.tiles[i] >= 0 && t.tiles[i] < len(t.frames) {
+		draw.Copy(t.buffer, rect.Min, t.frames[t.tiles[i]], t.frames[t.tiles[i]].Bounds(), draw.Src, nil)
+	}
+	t.dirty = t.dirty.Union(rect)
+}
+
+// dirtyChunks gets the chunks that show a part of the dirty rectangle
+func (t *tilemap) dirtyChunks() []int {
+	chunks := []int{}
+	for i, rect := range t.rects {
+		if rect.Overlaps(t.dirty) {
+			chunks = append(chunks, i)
+		}
+	}
+	return chunks
+}
+
+// applyOpacity makes the chunks as translucent as the frame of the clip
+func (t *tilemap) applyOpacity(translucency float64) {
+	for _, chunk := range t.chunks {
+		chunk.Translucency = translucency
+		chunk.Refresh()
+	}
+}
+
+// GetTileCount gets the number of tiles of a tilemap clip
+func (c *Clip) GetTileCount() int {
+	if c.tilemap == nil {
+		return 0
+	}
+	return len(c.tilemap.tiles)
+}
+
+// GetGrid gets the number of columns and rows of a tilemap clip
+func (c *Clip) GetGrid() (int, int) {
+	if c.tilemap == nil {
+		return 0, 0
+	}
+	return c.tilemap.columns, c.tilemap.rows
+}
+
+// GetTile gets the frame of a tile of a tilemap clip
+func (c *Clip) GetTile(i int) int {
+	if c.tilemap == nil || i < 0 || i >= len(c.tilemap.tiles) {
+		return -1
+	}
+	return c.tilemap.tiles[i]
+}
+
+// SetTile sets the frame of a tile of a tilemap clip, only the tile is redrawn
+func (c *Clip) SetTile(i, frame int, refresh bool) {
+	t := c.tilemap
+	if t == nil || i < 0 || i >= len(t.tiles) || frame < 0 || frame >= len(t.frames) {
+		return
+	}
+	if t.tiles[i] != frame {
+		t.tiles[i] = frame
+		t.draw(i)
+	}
+	if refresh {
+		c.RefreshTiles()
+	}
+}
+
+// SetTileByName sets a tile of a tilemap clip to a named frame, it returns
+// an error when the clip has no frame with that name
+func (c *Clip) SetTileByName(i int, name string, refresh bool) error {
+	frame := c.GetFrameIndex(name)
+	if frame < 0 {
+		return fmt.Errorf("clip '%s': frame '%s' not found", c.name, name)
+	}
+	c.SetTile(i, frame, refresh)
+	return nil
+}
+
+// RefreshTiles shows the tiles that have changed since the last refresh,
+// only the chunks that show a part of the dirty rectangle are refreshed
+func (c *Clip) RefreshTiles() {
+	t := c.tilemap
+	if t == nil || t.dirty.Empty() {
+		return
+	}
+	for _, i := range t.dirtyChunks() {
+		t.chunks[i].Refresh()
+	}
+	t.dirty = image.Rectangle{}
+}
+
+// TileAt gets the index of the tile at a position relative to the clip,
+// or -1 when there is no tile at that position
+func (c *Clip) TileAt(pos fyne.Position) int {
+	t := c.tilemap
+	if t == nil || pos.X < 0 || pos.Y < 0 {
+		return -1
+	}
+	column := int(pos.X) / (t.tileWidth * c.scale)
+	row := int(pos.Y) / (t.tileHeight * c.scale)
+	if column >= t.columns || row >= t.rows {
+		return -1
+	}
+	return row*t.columns + column
+}
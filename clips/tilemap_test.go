@@ -0,0 +1,159 @@
+package clips
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+	"github.com/mevdschee/fyne-mines/sprites"
+	"golang.org/x/image/draw"
+)
+
+func TestTilemap(t *testing.T) {
+	test.NewApp()
+	sprite := newTestSprite(3)
+	sprite.Frames[2].Name = "two"
+	clip := NewTilemap(sprite, "tiles", 0, 0, 3, 2, 1)
+	if !clip.tilemap.dirty.Empty() {
+		t.Error("new tilemap is dirty")
+	}
+	clip.SetTile(4, 1, false)
+	clip.SetTile(4, 1, false)
+	err := clip.SetTileByName(5, "two", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !clip.tilemap.dirty.Empty() {
+		t.Error("tilemap is dirty after refresh")
+	}
+	if got := []int{clip.GetTile(0), clip.GetTile(4), clip.GetTile(5)}; got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("tiles are %v, want [0 1 2]", got)
+	}
+	if clip.SetTileByName(0, "three", true) == nil {
+		t.Error("no error for a frame that does not exist")
+	}
+	if i := clip.TileAt(fyne.NewPos(9, 5)); i != 5 {
+		t.Errorf("tile at (9,5) is %d, want 5", i)
+	}
+}
+
+// TestTilemapChunks changes tiles of a tilemap of 3x2 chunks, only the
+// chunks with changed tiles are refreshed and they show the buffer
+func TestTilemapChunks(t *testing.T) {
+	test.NewApp()
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 4))
+	draw.Draw(img, image.Rect(4, 0, 8, 4), image.NewUniform(color.NRGBA{R: 255, A: 255}), image.Point{}, draw.Src)
+	var src image.Image = img
+	sprite := &sprites.Sprite{Image: &src, Name: "test", Width: 4, Height: 4, Count: 2, Grid: 2}
+	for i := 0; i < 2; i++ {
+		sprite.Frames = append(sprite.Frames, &sprites.Frame{X: i * 4, Width: 4, Height: 4})
+	}
+	clip := NewTilemap(sprite, "tiles", 0, 0, 20, 10, 2)
+	tiles := clip.tilemap
+	if len(tiles.chunks) != 6 || tiles.rects[5] != image.Rect(64, 32, 80, 40) {
+		t.Fatalf("tilemap has %d chunks, the last at %v", len(tiles.chunks), tiles.rects[len(tiles.rects)-1])
+	}
+	clip.SetTile(9, 1, false)
+	if chunks := tiles.dirtyChunks(); len(chunks) != 1 || chunks[0] != 1 {
+		t.Errorf("dirty chunks are %v, want [1]", chunks)
+	}
+	clip.SetTile(9*20+19, 1, false)
+	if chunks := tiles.dirtyChunks(); len(chunks) != 4 {
+		t.Errorf("dirty chunks are %v, want [1 2 4 5]", chunks)
+	}
+	w := test.NewWindow(container.NewWithoutLayout(clip.GetContainer()))
+	defer w.Close()
+	w.SetPadded(false)
+	w.Resize(fyne.NewSize(160, 80))
+	clip.GetContainer().Resize(clip.size())
+	clip.RefreshTiles()
+	if !tiles.dirty.Empty() {
+		t.Error("tilemap is dirty after refresh")
+	}
+	capture := w.Canvas().Capture()
+	for _, p := range []struct {
+		x, y int
+		red  bool
+	}{{1, 1, false}, {9*8 + 1, 1, true}, {19*8 + 7, 9*8 + 7, true}, {18*8 + 7, 9*8 + 7, false}} {
+		r, _, _, _ := capture.At(p.x, p.y).RGBA()
+		if (r == 0xffff) != p.red {
+			t.Errorf("pixel at (%d,%d) red is %v, want %v", p.x, p.y, r == 0xffff, p.red)
+		}
+	}
+}
+
+// the board of a large custom game in tiles of 16x16
+const benchColumns, benchRows = 100, 100
+
+// newBoardSprite creates a sprite of 9 tiles of 16x16
+func newBoardSprite() *sprites.Sprite {
+	var img image.Image = image.NewNRGBA(image.Rect(0, 0, 16*9, 16))
+	sprite := &sprites.Sprite{Image: &img, Name: "tiles", Width: 16, Height: 16, Count: 9, Grid: 9}
+	for i := 0; i < 9; i++ {
+		sprite.Frames = append(sprite.Frames, &sprites.Frame{X: i * 16, Width: 16, Height: 16})
+	}
+	return sprite
+}
+
+// newClipBoard creates a board with a clip per tile
+func newClipBoard(sprite *sprites.Sprite) (*fyne.Container, []*Clip) {
+	board := container.NewWithoutLayout()
+	tiles := []*Clip{}
+	for i := 0; i < benchColumns*benchRows; i++ {
+		clip := New(sprite, "tile", (i%benchColumns)*16, (i/benchColumns)*16, 1)
+		tiles = append(tiles, clip)
+		board.Add(clip.GetContainer())
+	}
+	return board, tiles
+}
+
+// BenchmarkTilemapNew creates the board as a tilemap
+func BenchmarkTilemapNew(b *testing.B) {
+	sprite := newBoardSprite()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewTilemap(sprite, "tiles", 0, 0, benchColumns, benchRows, 1)
+	}
+}
+
+// BenchmarkClipBoardNew creates the board with a clip per tile
+func BenchmarkClipBoardNew(b *testing.B) {
+	sprite := newBoardSprite()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		newClipBoard(sprite)
+	}
+}
+
+// BenchmarkTilemapUpdate changes a tile of the board as a tilemap in a
+// window and refreshes it
+func BenchmarkTilemapUpdate(b *testing.B) {
+	test.NewApp()
+	clip := NewTilemap(newBoardSprite(), "tiles", 0, 0, benchColumns, benchRows, 1)
+	clip.GetContainer().Resize(fyne.NewSize(benchColumns*16, benchRows*16))
+	w := test.NewWindow(container.NewWithoutLayout(clip.GetContainer()))
+	defer w.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clip.SetTile(i%(benchColumns*benchRows), i%9, true)
+	}
+}
+
+// BenchmarkClipBoardUpdate changes a tile of the board with a clip per tile
+// in a window and refreshes it
+func BenchmarkClipBoardUpdate(b *testing.B) {
+	test.NewApp()
+	board, tiles := newClipBoard(newBoardSprite())
+	board.Resize(fyne.NewSize(benchColumns*16, benchRows*16))
+	w := test.NewWindow(board)
+	defer w.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tiles[i%len(tiles)].GotoFrame(i%9, true)
+	}
+}
@@ -0,0 +1,228 @@
+package clips
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Easing maps the progress of a tween (0 to 1) to the progress of the value
+type Easing func(t float64) float64
+
+// Linear moves at a constant speed
+func Linear(t float64) float64 {
+	return t
+}
+
+// EaseInQuad accelerates from zero speed
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad decelerates to zero speed
+func EaseOutQuad(t float64) float64 {
+	return t * (2 - t)
+}
+
+// EaseInOutQuad accelerates until halfway, then decelerates
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// EaseInCubic accelerates from zero speed
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOutCubic decelerates to zero speed
+func EaseOutCubic(t float64) float64 {
+	t--
+	return t*t*t + 1
+}
+
+// EaseInOutCubic accelerates until halfway, then decelerates
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return t*t*t/2 + 1
+}
+
+// EaseInElastic winds up like a spring before moving
+func EaseInElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*(2*math.Pi)/3)
+}
+
+// EaseOutElastic overshoots and springs back into place
+func EaseOutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*(2*math.Pi)/3) + 1
+}
+
+// Tween animates a value from 0 to 1 over time
+type Tween struct {
+	mutex      sync.Mutex
+	duration   time.Duration
+	elapsed    time.Duration
+	easing     Easing
+	clip       *Clip
+	setups     []func() func(v float64)
+	steps      []func(v float64)
+	onComplete func()
+	running    bool
+}
+
+// NewTween creates a new tween that calls step with the eased progress
+func NewTween(duration time.Duration, easing Easing, step func(v float64)) *Tween {
+	if easing == nil {
+		easing = Linear
+	}
+	t := &Tween{
+		duration: duration,
+		easing:   easing,
+		steps:    []func(v float64){},
+	}
+	if step != nil {
+		t.steps = append(t.steps, step)
+	}
+	return t
+}
+
+// OnComplete sets the handler that is called when the tween is finished
+func (t *Tween) OnComplete(handler func()) *Tween {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.onComplete = handler
+	return t
+}
+
+// Start starts the tween
+func (t *Tween) Start() *Tween {
+	t.mutex.Lock()
+	for _, setup := range t.setups {
+		t.steps = append(t.steps, setup())
+	}
+	t.setups = nil
+	t.elapsed = 0
+	t.running = true
+	t.mutex.Unlock()
+	startTicking(t)
+	return t
+}
+
+// Cancel stops the tween where it is without calling the complete handler
+func (t *Tween) Cancel() {
+	t.mutex.Lock()
+	t.running = false
+	t.mutex.Unlock()
+	stopTicking(t)
+}
+
+// IsRunning returns whether the tween is running
+func (t *Tween) IsRunning() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.running
+}
+
+// tick advances the tween, it is called by the shared ticker
+func (t *Tween) tick(elapsed time.Duration) bool {
+	t.mutex.Lock()
+	if !t.running {
+		t.mutex.Unlock()
+		return false
+	}
+	t.elapsed += elapsed
+	progress := 1.0
+	if t.duration > 0 && t.elapsed < t.duration {
+		progress = float64(t.elapsed) / float64(t.duration)
+	}
+	done := progress >= 1
+	if done {
+		t.running = false
+	}
+	steps, onComplete := t.steps, t.onComplete
+	t.mutex.Unlock()
+	v := t.easing(progress)
+	for _, step := range steps {
+		step(v)
+	}
+	if done && onComplete != nil {
+		onComplete()
+	}
+	return t.IsRunning()
+}
+
+// Tween creates a tween for the clip that can be configured using MoveTo,
+// ResizeTo and FadeTo before it is started
+func (c *Clip) Tween(duration time.Duration, easing Easing) *Tween {
+	t := NewTween(duration, easing, nil)
+	t.clip = c
+	tweens := []*Tween{t}
+	for _, tween := range c.tweens {
+		if tween.IsRunning() {
+			tweens = append(tweens, tween)
+		}
+	}
+	c.tweens = tweens
+	return t
+}
+
+// CancelTweens cancels all tweens of the clip
+func (c *Clip) CancelTweens() {
+	for _, t := range c.tweens {
+		t.Cancel()
+	}
+	c.tweens = c.tweens[:0]
+}
+
+// MoveTo moves the clip of the tween to a position (in unscaled pixels)
+func (t *Tween) MoveTo(x, y int) *Tween {
+	t.setups = append(t.setups, func() func(v float64) {
+		c := t.clip
+		from := c.GetBounds()
+		fromX, fromY := from.Min.X, from.Min.Y
+		return func(v float64) {
+			c.Move(lerp(fromX, x, v), lerp(fromY, y, v))
+		}
+	})
+	return t
+}
+
+// ResizeTo resizes the clip of the tween (in unscaled pixels)
+func (t *Tween) ResizeTo(width, height int) *Tween {
+	t.setups = append(t.setups, func() func(v float64) {
+		c := t.clip
+		from := c.GetBounds()
+		fromWidth, fromHeight := from.Dx(), from.Dy()
+		return func(v float64) {
+			c.Resize(lerp(fromWidth, width, v), lerp(fromHeight, height, v))
+		}
+	})
+	return t
+}
+
+// FadeTo changes the opacity of the clip of the tween
+func (t *Tween) FadeTo(opacity float64) *Tween {
+	t.setups = append(t.setups, func() func(v float64) {
+		c := t.clip
+		from := c.GetOpacity()
+		return func(v float64) {
+			c.SetOpacity(from + (opacity-from)*v)
+		}
+	})
+	return t
+}
+
+func lerp(from, to int, v float64) int {
+	return from + int(math.Round(float64(to-from)*v))
+}
@@ -0,0 +1,137 @@
+package clips
+
+import (
+	"image"
+	"math"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestEasing(t *testing.T) {
+	easings := map[string]Easing{
+		"Linear":         Linear,
+		"EaseInQuad":     EaseInQuad,
+		"EaseOutQuad":    EaseOutQuad,
+		"EaseInOutQuad":  EaseInOutQuad,
+		"EaseInCubic":    EaseInCubic,
+		"EaseOutCubic":   EaseOutCubic,
+		"EaseInOutCubic": EaseInOutCubic,
+		"EaseInElastic":  EaseInElastic,
+		"EaseOutElastic": EaseOutElastic,
+	}
+	for name, easing := range easings {
+		if v := easing(0); v != 0 {
+			t.Errorf("%s(0) = %v, want 0", name, v)
+		}
+		if v := easing(1); math.Abs(v-1) > 1e-9 {
+			t.Errorf("%s(1) = %v, want 1", name, v)
+		}
+	}
+	// an elastic easing goes past the range of the value before settling
+	min, max := 0.0, 1.0
+	for i := 1; i < 100; i++ {
+		min = math.Min(min, EaseInElastic(float64(i)/100))
+		max = math.Max(max, EaseOutElastic(float64(i)/100))
+	}
+	if min >= 0 {
+		t.Errorf("EaseInElastic does not go below 0")
+	}
+	if max <= 1 {
+		t.Errorf("EaseOutElastic does not overshoot 1")
+	}
+}
+
+func TestTween(t *testing.T) {
+	manualTicker(t)
+	values := []float64{}
+	completed := 0
+	tween := NewTween(100*time.Millisecond, EaseInQuad, func(v float64) {
+		values = append(values, v)
+	}).OnComplete(func() {
+		completed++
+	}).Start()
+	for _, elapsed := range []time.Duration{0, 25, 25, 50, 25} {
+		step(elapsed * time.Millisecond)
+	}
+	want := []float64{0, 0.0625, 0.25, 1}
+	if len(values) != len(want) {
+		t.Fatalf("tween stepped %v, want %v", values, want)
+	}
+	for i := range want {
+		if math.Abs(values[i]-want[i]) > 1e-9 {
+			t.Errorf("tween stepped %v, want %v", values, want)
+			break
+		}
+	}
+	if completed != 1 {
+		t.Errorf("complete handler called %d times, want once", completed)
+	}
+	if tween.IsRunning() || isTicking(tween) {
+		t.Error("tween is still running after it completed")
+	}
+}
+
+func TestTweenCancel(t *testing.T) {
+	manualTicker(t)
+	values := []float64{}
+	completed := 0
+	tween := NewTween(100*time.Millisecond, Linear, func(v float64) {
+		values = append(values, v)
+	}).OnComplete(func() {
+		completed++
+	}).Start()
+	step(50 * time.Millisecond)
+	tween.Cancel()
+	step(50 * time.Millisecond)
+	step(50 * time.Millisecond)
+	if len(values) != 1 || values[0] != 0.5 {
+		t.Errorf("cancelled tween stepped %v, want [0.5]", values)
+	}
+	if completed != 0 {
+		t.Errorf("complete handler of a cancelled tween called %d times", completed)
+	}
+	if tween.IsRunning() || isTicking(tween) {
+		t.Error("tween is still running after it was cancelled")
+	}
+}
+
+// TestTweenScale tweens a clip with a scale of 2, the tween works in
+// unscaled pixels and the container of the clip is moved and sized scaled
+func TestTweenScale(t *testing.T) {
+	test.NewApp()
+	manualTicker(t)
+	clip := New(newTestSprite(1), "clip", 2, 4, 2)
+	clip.Tween(100*time.Millisecond, Linear).MoveTo(12, 8).ResizeTo(8, 6).FadeTo(0.5).Start()
+	tests := []struct {
+		elapsed time.Duration
+		bounds  image.Rectangle
+		opacity float64
+	}{
+		{50 * time.Millisecond, image.Rect(7, 6, 13, 11), 0.75},
+		{50 * time.Millisecond, image.Rect(12, 8, 20, 14), 0.5},
+	}
+	for _, tt := range tests {
+		step(tt.elapsed)
+		if bounds := clip.GetBounds(); bounds != tt.bounds {
+			t.Errorf("clip at %v, want %v", bounds, tt.bounds)
+		}
+		container := clip.GetContainer()
+		position := fyne.NewPos(float32(2*tt.bounds.Min.X), float32(2*tt.bounds.Min.Y))
+		size := fyne.NewSize(float32(2*tt.bounds.Dx()), float32(2*tt.bounds.Dy()))
+		if container.Position() != position || container.Size() != size {
+			t.Errorf("container at %v of %v, want %v of %v", container.Position(), container.Size(), position, size)
+		}
+		if opacity := clip.GetOpacity(); opacity != tt.opacity {
+			t.Errorf("clip has opacity %v, want %v", opacity, tt.opacity)
+		}
+	}
+	// a new tween starts from where the clip is
+	clip.Tween(100*time.Millisecond, Linear).MoveTo(2, 8).Start()
+	step(50 * time.Millisecond)
+	if bounds := clip.GetBounds(); bounds.Min != image.Pt(7, 8) {
+		t.Errorf("clip at %v, want (7,8)", bounds.Min)
+	}
+}
@@ -0,0 +1,53 @@
+package clips
+
+import "math"
+
+// Stacker keeps clips in the order they are drawn, a layer is one
+type Stacker interface {
+	MoveClipToTop(clip *Clip)
+	MoveClipToBottom(clip *Clip)
+}
+
+// IsVisible returns whether the clip is shown
+func (c *Clip) IsVisible() bool {
+	return c.container.Visible()
+}
+
+// SetVisible shows or hides the clip, a hidden clip gets no events
+func (c *Clip) SetVisible(visible bool) {
+	if c.container.Visible() == visible {
+		return
+	}
+	if visible {
+		c.container.Show()
+	} else {
+		c.container.Hide()
+	}
+}
+
+// SetLayerOpacity sets the opacity of the layer of the clip (from 0 to 1),
+// it is multiplied with the opacity of the clip
+func (c *Clip) SetLayerOpacity(opacity float64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	opacity = math.Max(0, math.Min(1, opacity))
+	if c.layerOpacity == opacity {
+		return
+	}
+	c.layerOpacity = opacity
+	c.applyOpacity()
+}
+
+// MoveToTop draws the clip above the other clips of its layer
+func (c *Clip) MoveToTop() {
+	if s, ok := c.parent.(Stacker); ok {
+		s.MoveClipToTop(c)
+	}
+}
+
+// MoveToBottom draws the clip below the other clips of its layer
+func (c *Clip) MoveToBottom() {
+	if s, ok := c.parent.(Stacker); ok {
+		s.MoveClipToBottom(c)
+	}
+}
@@ -0,0 +1,130 @@
+// Command movielint checks a movie JSON file against sprite meta JSON
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"github.com/mevdschee/fyne-mines/layers"
+	"github.com/mevdschee/fyne-mines/movies"
+	"github.com/mevdschee/fyne-mines/sprites"
+)
+
+// parameterFlags collects the repeated -p name=value flags
+type parameterFlags map[string]interface{}
+
+func (p parameterFlags) String() string {
+	pairs := []string{}
+	for name, value := range p {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", name, value))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (p parameterFlags) Set(pair string) error {
+	name, value, ok := strings.Cut(pair, "=")
+	if !ok {
+		return fmt.Errorf("parameter '%s' is not in the form name=value", pair)
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("parameter '%s' is not an integer", pair)
+	}
+	p[name] = number
+	return nil
+}
+
+func main() {
+	parameters := parameterFlags{}
+	spritesFile := flag.String("sprites", "", "sprite meta JSON file (required)")
+	movieFile := flag.String("movie", "", "movie JSON file (required)")
+	imageFile := flag.String("image", "", "sprite sheet PNG file, to check the sprites lie on it")
+	width := flag.String("width", "", "canvas width expression, to check the clips lie on it")
+	height := flag.String("height", "", "canvas height expression, to check the clips lie on it")
+	flag.Var(parameters, "p", "parameter in the form name=value (repeatable)")
+	flag.Parse()
+	if *spritesFile == "" || *movieFile == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	errs, err := lint(*spritesFile, *movieFile, *imageFile, *width, *height, parameters)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	if len(errs) > 0 {
+		os.Exit(1)
+	}
+}
+
+func lint(spritesFile, movieFile, imageFile, width, height string, parameters map[string]interface{}) ([]error, error) {
+	meta, err := os.ReadFile(spritesFile)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(movieFile)
+	if err != nil {
+		return nil, err
+	}
+	var spriteMap sprites.SpriteMap
+	if imageFile != "" {
+		imageData, err := os.ReadFile(imageFile)
+		if err != nil {
+			return nil, err
+		}
+		spriteMap, err = sprites.NewSpriteMap(imageData, string(meta))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", spritesFile, err)
+		}
+	} else {
+		spriteMap, err = sprites.ParseSpriteMap(string(meta))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", spritesFile, err)
+		}
+	}
+	errs := []error{}
+	for _, err := range sprites.Validate(spriteMap) {
+		errs = append(errs, fmt.Errorf("%s: %v", spritesFile, err))
+	}
+	bounds := image.Rectangle{}
+	if width != "" || height != "" {
+		w, err := evalSize("width", width, parameters)
+		if err != nil {
+			return nil, err
+		}
+		h, err := evalSize("height", height, parameters)
+		if err != nil {
+			return nil, err
+		}
+		bounds = image.Rect(0, 0, w, h)
+	}
+	for _, err := range movies.Validate(spriteMap, string(data), parameters, layers.NewFunctions(), bounds) {
+		errs = append(errs, fmt.Errorf("%s: %v", movieFile, err))
+	}
+	return errs, nil
+}
+
+func evalSize(name, expression string, parameters map[string]interface{}) (int, error) {
+	if expression == "" {
+		return 0, fmt.Errorf("both width and height are needed to check the canvas")
+	}
+	value, err := expr.Eval(expression, parameters)
+	if err != nil {
+		return 0, fmt.Errorf("%s in '%s': %v", name, expression, err)
+	}
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		return int(v), nil
+	}
+	return 0, fmt.Errorf("%s in '%s': not a number", name, expression)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"image/color"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/fsnotify/fsnotify"
+)
+
+// devDir is the directory with the sprite meta and movie JSON in dev mode
+var devDir string
+
+const (
+	devSpritesFile = "sprites.json"
+	devMovieFile   = "movie.json"
+)
+
+// readSources reads the sprite meta and movie JSON, from the dev directory
+// in dev mode, files that do not exist are created with the built-in JSON
+func readSources() (string, string, error) {
+	if devDir == "" {
+		return spriteMapMeta, movieScenes, nil
+	}
+	meta, err := readSource(devSpritesFile, spriteMapMeta)
+	if err != nil {
+		return "", "", err
+	}
+	scenes, err := readSource(devMovieFile, movieScenes)
+	if err != nil {
+		return "", "", err
+	}
+	return meta, scenes, nil
+}
+
+func readSource(name, fallback string) (string, error) {
+	filename := filepath.Join(devDir, name)
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fallback, os.WriteFile(filename, []byte(fallback), 0644)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// watchSources calls onChange when the sprite meta or movie JSON changes
+func watchSources(onChange func()) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	err = watcher.Add(devDir)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	go func() {
+		var timer *time.Timer
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				name := filepath.Base(event.Name)
+				if name != devSpritesFile && name != devMovieFile {
+					continue
+				}
+				if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+					continue
+				}
+				// editors write files in several steps, so wait for them to settle
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(100*time.Millisecond, onChange)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Println(err)
+			}
+		}
+	}()
+}
+
+func newErrorOverlay(err error) fyne.CanvasObject {
+	background := canvas.NewRectangle(color.NRGBA{128, 0, 0, 220})
+	label := widget.NewLabel(err.Error())
+	label.Wrapping = fyne.TextWrapWord
+	label.Importance = widget.DangerImportance
+	return container.NewStack(background, container.NewVScroll(label))
+}
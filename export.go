@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mevdschee/fyne-mines/movies"
+	"golang.org/x/image/draw"
+)
+
+// recording is a game recorded as one snapshot per state change, the frames
+// of the animation are only rendered when it is exported
+type recording struct {
+	snapshots []snapshot
+}
+
+// snapshot is the state of the board after a move
+type snapshot struct {
+	time    time.Time
+	button  string
+	bombs   int
+	seconds int
+	icons   []string
+}
+
+// maxRecordedFrames limits the memory used for recording a game
+const maxRecordedFrames = 2000
+
+// record adds the current board to the recording of the game
+func (g *game) record() {
+	if len(g.recording.snapshots) >= maxRecordedFrames {
+		return
+	}
+	g.recording.snapshots = append(g.recording.snapshots, snapshot{
+		time:    time.Now(),
+		button:  g.button,
+		bombs:   g.board.GetRemaining(),
+		seconds: g.seconds,
+		icons:   g.board.GetIcons(),
+	})
+}
+
+// renderSnapshot renders a recorded board with a movie that is not shown
+func renderSnapshot(movie *movies.Movie, s snapshot) (image.Image, error) {
+	for name, value := range map[string]interface{}{"button": s.button, "bombs": s.bombs, "seconds": s.seconds} {
+		err := movie.Set(name, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	icons, err := movie.GetClips("game", "board", "icons")
+	if err != nil {
+		return nil, err
+	}
+	if len(icons) != len(s.icons) {
+		return nil, fmt.Errorf("recorded %d tiles, board has %d", len(s.icons), len(icons))
+	}
+	for i, icon := range icons {
+		err = icon.GotoFrameByName(s.icons[i], false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return movie.Render("game")
+}
+
+func scaleImage(img image.Image, scale int) image.Image {
+	if scale <= 1 {
+		return img
+	}
+	bounds := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx()*scale, bounds.Dy()*scale))
+	draw.NearestNeighbor.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// writeScreenshot writes the current board as a PNG
+func (g *game) writeScreenshot(w io.Writer, scale int) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	img, err := g.movie.Render("game")
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, scaleImage(img, scale))
+}
+
+// writeAnimation writes the recorded game as an animated GIF
+func (g *game) writeAnimation(w io.Writer, scale int) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	snapshots := g.recording.snapshots
+	if len(snapshots) == 0 {
+		return fmt.Errorf("no game has been recorded")
+	}
+	meta, scenes, err := readSources()
+	if err != nil {
+		return err
+	}
+	movie, err := g.loadMovie(meta, scenes)
+	if err != nil {
+		return err
+	}
+	colors := skinPalette()
+	animation := &gif.GIF{}
+	for i, s := range snapshots {
+		frame, err := renderSnapshot(movie, s)
+		if err != nil {
+			return err
+		}
+		img := scaleImage(frame, scale)
+		paletted := image.NewPaletted(img.Bounds(), colors)
+		draw.Draw(paletted, paletted.Bounds(), img, image.Point{}, draw.Src)
+		// show every state for as long as it lasted, within limits
+		delay := 200
+		if i+1 < len(snapshots) {
+			delay = int(snapshots[i+1].time.Sub(s.time) / (10 * time.Millisecond))
+		}
+		if delay < 10 {
+			delay = 10
+		}
+		if delay > 200 {
+			delay = 200
+		}
+		animation.Image = append(animation.Image, paletted)
+		animation.Delay = append(animation.Delay, delay)
+	}
+	return gif.EncodeAll(w, animation)
+}
+
+// skinPalette gets the colors of the skin, as the skin is a paletted PNG
+func skinPalette() color.Palette {
+	img, err := png.Decode(bytes.NewReader(resourceWinxpskinPng.Content()))
+	if err == nil {
+		if paletted, ok := img.(*image.Paletted); ok {
+			return paletted.Palette
+		}
+	}
+	return palette.Plan9
+}
+
+// maxExportScale limits the size of the exported images
+const maxExportScale = 8
+
+// parseScale reads the scale of an export as a whole number from 1 to
+// maxExportScale, an "x" after the number is allowed
+func parseScale(text string) (int, error) {
+	scale, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(text), "x"))
+	if err != nil {
+		return 0, fmt.Errorf("scale must be a whole number")
+	}
+	if scale < 1 || scale > maxExportScale {
+		return 0, fmt.Errorf("scale must be from 1 to %d", maxExportScale)
+	}
+	return scale, nil
+}
+
+// showExportDialog asks for a scale and a file and then writes the export
+func showExportDialog(title, extension string, scale int, write func(w io.Writer, scale int) error, window fyne.Window) {
+	if scale < 1 || scale > maxExportScale {
+		scale = 1
+	}
+	entryScale := widget.NewEntry()
+	entryScale.SetText(strconv.Itoa(scale))
+	entryScale.Validator = func(text string) error {
+		_, err := parseScale(text)
+		return err
+	}
+	item := widget.NewFormItem("Scale", entryScale)
+	item.HintText = fmt.Sprintf("From 1 to %d", maxExportScale)
+	items := []*widget.FormItem{item}
+	dialog.ShowForm(title, "Save...", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		scale, err := parseScale(entryScale.Text)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			defer writer.Close()
+			err = write(writer, scale)
+			if err != nil {
+				dialog.ShowError(err, window)
+			}
+		}, window)
+		save.SetFileName("fyne-mines" + extension)
+		save.SetFilter(storage.NewExtensionFileFilter([]string{extension}))
+		save.Show()
+	}, window)
+}
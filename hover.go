@@ -0,0 +1,54 @@
+package main
+
+import "image/color"
+
+// hoverColor is drawn over the tiles that a click would affect
+var hoverColor = color.NRGBA{R: 255, G: 255, B: 255, A: 64}
+
+// hover sets the tile under the pointer, -1 when there is none
+func (g *game) hover(x, y int) {
+	if g.hoverX == x && g.hoverY == y {
+		return
+	}
+	g.hoverX, g.hoverY = x, y
+	g.updateHover()
+}
+
+// setHoverEnabled turns the hover highlight on or off
+func (g *game) setHoverEnabled(enabled bool) {
+	g.c.hover = enabled
+	g.updateHover()
+}
+
+// updateHover highlights the closed tile under the pointer or the closed
+// neighbours that a chord on the open tile under the pointer would open,
+// only the tiles of which the highlight changes are refreshed
+func (g *game) updateHover() {
+	hovered := map[int]bool{}
+	px, py := g.hoverX, g.hoverY
+	if g.c.hover && !g.board.IsOver() && px >= 0 && py >= 0 && px < g.c.width && py < g.c.height {
+		if t := g.board.GetTile(px, py); !t.Open {
+			if !t.Marked {
+				hovered[py*g.c.width+px] = true
+			}
+		} else if t.Number > 0 {
+			g.board.ForEachNeighbour(px, py, func(x, y int) {
+				if t := g.board.GetTile(x, y); !t.Open && !t.Marked {
+					hovered[y*g.c.width+x] = true
+				}
+			})
+		}
+	}
+	icons := g.getClips("board", "icons")
+	for i := range g.hovered {
+		if !hovered[i] {
+			icons[i].SetHighlight(nil)
+		}
+	}
+	for i := range hovered {
+		if !g.hovered[i] {
+			icons[i].SetHighlight(hoverColor)
+		}
+	}
+	g.hovered = hovered
+}
@@ -0,0 +1,117 @@
+package interactive
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ensure Focusable and Tabbable
+var _ fyne.Focusable = (*FocusArea)(nil)
+var _ fyne.Tabbable = (*FocusArea)(nil)
+
+// FocusArea is an object that can have the keyboard focus and that shows a
+// focus ring while it has it, it also receives the tab key so the owner can
+// decide what gets the focus next
+type FocusArea struct {
+	widget.BaseWidget
+	focused        bool
+	onFocusChanged func(focused bool)
+	onTypedKey     func(ev *fyne.KeyEvent)
+	onTypedRune    func(r rune)
+}
+
+func NewFocusArea() *FocusArea {
+	f := &FocusArea{}
+	f.ExtendBaseWidget(f)
+	return f
+}
+
+func (f *FocusArea) CreateRenderer() fyne.WidgetRenderer {
+	ring := canvas.NewRectangle(color.Transparent)
+	ring.StrokeColor = theme.Color(theme.ColorNameFocus)
+	ring.StrokeWidth = 2
+	ring.Hidden = !f.focused
+	return &focusRenderer{area: f, ring: ring}
+}
+
+// IsFocused returns whether the area has the keyboard focus
+func (f *FocusArea) IsFocused() bool {
+	return f.focused
+}
+
+// OnFocusChanged sets the handler that is called when the focus is gained or lost
+func (f *FocusArea) OnFocusChanged(handler func(focused bool)) {
+	f.onFocusChanged = handler
+}
+
+func (f *FocusArea) FocusGained() {
+	f.focused = true
+	f.Refresh()
+	if f.onFocusChanged != nil {
+		f.onFocusChanged(true)
+	}
+}
+
+func (f *FocusArea) FocusLost() {
+	f.focused = false
+	f.Refresh()
+	if f.onFocusChanged != nil {
+		f.onFocusChanged(false)
+	}
+}
+
+// OnTypedKey sets the key handler
+func (f *FocusArea) OnTypedKey(handler func(ev *fyne.KeyEvent)) {
+	f.onTypedKey = handler
+}
+
+func (f *FocusArea) TypedKey(ev *fyne.KeyEvent) {
+	if f.onTypedKey != nil {
+		f.onTypedKey(ev)
+	}
+}
+
+// OnTypedRune sets the rune handler
+func (f *FocusArea) OnTypedRune(handler func(r rune)) {
+	f.onTypedRune = handler
+}
+
+func (f *FocusArea) TypedRune(r rune) {
+	if f.onTypedRune != nil {
+		f.onTypedRune(r)
+	}
+}
+
+func (f *FocusArea) AcceptsTab() bool {
+	return true
+}
+
+// focusRenderer draws the focus ring of a focus area
+type focusRenderer struct {
+	area *FocusArea
+	ring *canvas.Rectangle
+}
+
+func (r *focusRenderer) Layout(size fyne.Size) {
+	r.ring.Resize(size)
+}
+
+func (r *focusRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
+func (r *focusRenderer) Refresh() {
+	r.ring.Hidden = !r.area.focused
+	r.ring.Refresh()
+}
+
+func (r *focusRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.ring}
+}
+
+func (r *focusRenderer) Destroy() {
+}
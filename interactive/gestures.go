@@ -0,0 +1,103 @@
+package interactive
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ensure Draggable, Scrollable and DoubleTappable
+var _ fyne.Draggable = (*DragArea)(nil)
+var _ fyne.Scrollable = (*ScrollArea)(nil)
+var _ fyne.DoubleTappable = (*DoubleTapImage)(nil)
+
+// DragArea is an invisible object that handles drags, it is placed below an
+// Image so that the mouse events keep going to the image under the pointer
+type DragArea struct {
+	widget.BaseWidget
+	onDragged func(ev *fyne.DragEvent)
+	onDragEnd func()
+}
+
+func NewDragArea() *DragArea {
+	d := &DragArea{}
+	d.ExtendBaseWidget(d)
+	return d
+}
+
+func (d *DragArea) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(canvas.NewRectangle(color.Transparent))
+}
+
+// OnDragged sets the drag handler
+func (d *DragArea) OnDragged(handler func(ev *fyne.DragEvent)) {
+	d.onDragged = handler
+}
+
+func (d *DragArea) Dragged(ev *fyne.DragEvent) {
+	if d.onDragged != nil {
+		d.onDragged(ev)
+	}
+}
+
+// OnDragEnd sets the drag end handler
+func (d *DragArea) OnDragEnd(handler func()) {
+	d.onDragEnd = handler
+}
+
+func (d *DragArea) DragEnd() {
+	if d.onDragEnd != nil {
+		d.onDragEnd()
+	}
+}
+
+// ScrollArea is an invisible object that handles the scroll wheel, it only
+// takes the scroll events away from the containers it is in when it exists
+type ScrollArea struct {
+	widget.BaseWidget
+	onScrolled func(ev *fyne.ScrollEvent)
+}
+
+func NewScrollArea() *ScrollArea {
+	s := &ScrollArea{}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+func (s *ScrollArea) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(canvas.NewRectangle(color.Transparent))
+}
+
+// OnScrolled sets the scroll handler
+func (s *ScrollArea) OnScrolled(handler func(ev *fyne.ScrollEvent)) {
+	s.onScrolled = handler
+}
+
+func (s *ScrollArea) Scrolled(ev *fyne.ScrollEvent) {
+	if s.onScrolled != nil {
+		s.onScrolled(ev)
+	}
+}
+
+// DoubleTapImage is an Image that also handles double taps, as its taps are
+// delayed until it is clear no second tap follows it is a separate type
+type DoubleTapImage struct {
+	*Image
+}
+
+func NewDoubleTapImage(image *Image) *DoubleTapImage {
+	return &DoubleTapImage{Image: image}
+}
+
+// OnDoubleTapped sets the double tap handler
+func (i *Image) OnDoubleTapped(handler func(ev *fyne.PointEvent)) {
+	i.onDoubleTapped = handler
+}
+
+func (i *DoubleTapImage) DoubleTapped(ev *fyne.PointEvent) {
+	if i.onDoubleTapped != nil {
+		i.onDoubleTapped(ev)
+	}
+}
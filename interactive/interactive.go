@@ -14,11 +14,19 @@ type Image struct {
 	onMouseIn    func(ev *desktop.MouseEvent)
 	onMouseOut   func()
 	onMouseMoved func(ev *desktop.MouseEvent)
+	cursor       desktop.Cursor
+
+	onTapped          func(ev *fyne.PointEvent)
+	onTappedSecondary func(ev *fyne.PointEvent)
+	onLongPress       func(ev *fyne.PointEvent)
+	onDoubleTapped    func(ev *fyne.PointEvent)
+	touch             touch
 }
 
-// ensure Mousable and Hoverable
+// ensure Mousable, Hoverable and Cursorable
 var _ desktop.Mouseable = (*Image)(nil)
 var _ desktop.Hoverable = (*Image)(nil)
+var _ desktop.Cursorable = (*Image)(nil)
 
 func NewImage(image *canvas.Image) *Image {
 	return &Image{Image: image}
@@ -82,3 +90,15 @@ func (i *Image) MouseMoved(ev *desktop.MouseEvent) {
 		i.onMouseMoved(ev)
 	}
 }
+
+// SetCursor sets the cursor that is shown over the image
+func (i *Image) SetCursor(cursor desktop.Cursor) {
+	i.cursor = cursor
+}
+
+func (i *Image) Cursor() desktop.Cursor {
+	if i.cursor == nil {
+		return desktop.DefaultCursor
+	}
+	return i.cursor
+}
@@ -0,0 +1,114 @@
+package interactive
+
+import (
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/mobile"
+)
+
+// LongPressDelay is how long a touch must be held to be a long press
+const LongPressDelay = 500 * time.Millisecond
+
+// ensure Tappable, SecondaryTappable and Touchable
+var _ fyne.Tappable = (*Image)(nil)
+var _ fyne.SecondaryTappable = (*Image)(nil)
+var _ mobile.Touchable = (*Image)(nil)
+
+// touch holds the state of the long press detector
+type touch struct {
+	mutex       sync.Mutex
+	timer       *time.Timer
+	longPressed bool
+}
+
+// OnTapped sets the tap handler
+func (i *Image) OnTapped(handler func(ev *fyne.PointEvent)) {
+	i.onTapped = handler
+}
+
+func (i *Image) Tapped(ev *fyne.PointEvent) {
+	if i.consumeLongPress() {
+		return
+	}
+	if i.onTapped != nil {
+		i.onTapped(ev)
+	}
+}
+
+// OnTappedSecondary sets the secondary tap handler
+func (i *Image) OnTappedSecondary(handler func(ev *fyne.PointEvent)) {
+	i.onTappedSecondary = handler
+}
+
+func (i *Image) TappedSecondary(ev *fyne.PointEvent) {
+	if i.consumeLongPress() {
+		return
+	}
+	if i.onTappedSecondary != nil {
+		i.onTappedSecondary(ev)
+	}
+}
+
+// OnLongPress sets the long press handler, it is called while the touch is
+// still held and the tap that follows is ignored, it runs on the goroutine of
+// the timer so the handler must guard the state that it shares with the UI
+func (i *Image) OnLongPress(handler func(ev *fyne.PointEvent)) {
+	i.onLongPress = handler
+}
+
+func (i *Image) TouchDown(ev *mobile.TouchEvent) {
+	handler := i.onLongPress
+	if handler == nil {
+		return
+	}
+	i.touch.mutex.Lock()
+	defer i.touch.mutex.Unlock()
+	if i.touch.timer != nil {
+		i.touch.timer.Stop()
+	}
+	i.touch.longPressed = false
+	point := ev.PointEvent
+	var timer *time.Timer
+	timer = time.AfterFunc(LongPressDelay, func() {
+		i.touch.mutex.Lock()
+		// a timer that was stopped or replaced may still fire
+		if i.touch.timer != timer {
+			i.touch.mutex.Unlock()
+			return
+		}
+		i.touch.longPressed = true
+		i.touch.timer = nil
+		i.touch.mutex.Unlock()
+		handler(&point)
+	})
+	i.touch.timer = timer
+}
+
+func (i *Image) TouchUp(ev *mobile.TouchEvent) {
+	i.stopLongPress()
+}
+
+func (i *Image) TouchCancel(ev *mobile.TouchEvent) {
+	i.stopLongPress()
+	i.consumeLongPress()
+}
+
+func (i *Image) stopLongPress() {
+	i.touch.mutex.Lock()
+	defer i.touch.mutex.Unlock()
+	if i.touch.timer != nil {
+		i.touch.timer.Stop()
+		i.touch.timer = nil
+	}
+}
+
+// consumeLongPress returns whether a long press just happened and resets it
+func (i *Image) consumeLongPress() bool {
+	i.touch.mutex.Lock()
+	defer i.touch.mutex.Unlock()
+	longPressed := i.touch.longPressed
+	i.touch.longPressed = false
+	return longPressed
+}
@@ -0,0 +1,106 @@
+package interactive
+
+import (
+	"image"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/mobile"
+	"fyne.io/fyne/v2/test"
+)
+
+// taps counts the taps and the long presses of an image
+type taps struct {
+	mutex       sync.Mutex
+	tapped      int
+	secondary   int
+	longPressed int
+	done        chan bool
+}
+
+func newTestImage(t *testing.T) (*Image, *taps) {
+	test.NewApp()
+	img := NewImage(canvas.NewImageFromImage(image.NewNRGBA(image.Rect(0, 0, 16, 16))))
+	img.Resize(fyne.NewSize(16, 16))
+	counts := &taps{done: make(chan bool, 1)}
+	img.OnTapped(func(ev *fyne.PointEvent) {
+		counts.mutex.Lock()
+		defer counts.mutex.Unlock()
+		counts.tapped++
+	})
+	img.OnTappedSecondary(func(ev *fyne.PointEvent) {
+		counts.mutex.Lock()
+		defer counts.mutex.Unlock()
+		counts.secondary++
+	})
+	img.OnLongPress(func(ev *fyne.PointEvent) {
+		counts.mutex.Lock()
+		counts.longPressed++
+		counts.mutex.Unlock()
+		counts.done <- true
+	})
+	return img, counts
+}
+
+func (c *taps) get() (int, int, int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.tapped, c.secondary, c.longPressed
+}
+
+func touchEvent() *mobile.TouchEvent {
+	return &mobile.TouchEvent{PointEvent: fyne.PointEvent{Position: fyne.NewPos(8, 8)}}
+}
+
+func TestTap(t *testing.T) {
+	img, counts := newTestImage(t)
+	img.TouchDown(touchEvent())
+	img.TouchUp(touchEvent())
+	test.Tap(img)
+	test.TapSecondary(img)
+	time.Sleep(LongPressDelay + 100*time.Millisecond)
+	if tapped, secondary, longPressed := counts.get(); tapped != 1 || secondary != 1 || longPressed != 0 {
+		t.Errorf("tapped %d, secondary %d, long pressed %d, want 1, 1 and 0", tapped, secondary, longPressed)
+	}
+}
+
+func TestLongPress(t *testing.T) {
+	img, counts := newTestImage(t)
+	img.TouchDown(touchEvent())
+	select {
+	case <-counts.done:
+	case <-time.After(5 * LongPressDelay):
+		t.Fatal("no long press")
+	}
+	img.TouchUp(touchEvent())
+	test.Tap(img)
+	test.Tap(img)
+	if tapped, _, longPressed := counts.get(); tapped != 1 || longPressed != 1 {
+		t.Errorf("tapped %d, long pressed %d, want the tap after the long press ignored", tapped, longPressed)
+	}
+}
+
+// TestTouchAgain touches again while the timer of the first touch runs, only
+// the last touch may become a long press
+func TestTouchAgain(t *testing.T) {
+	img, counts := newTestImage(t)
+	img.TouchDown(touchEvent())
+	time.Sleep(LongPressDelay / 2)
+	img.TouchUp(touchEvent())
+	test.Tap(img)
+	img.TouchDown(touchEvent())
+	select {
+	case <-counts.done:
+	case <-time.After(5 * LongPressDelay):
+		t.Fatal("no long press")
+	}
+	img.TouchCancel(touchEvent())
+	test.Tap(img)
+	time.Sleep(LongPressDelay)
+	if tapped, _, longPressed := counts.get(); tapped != 2 || longPressed != 1 {
+		t.Errorf("tapped %d, long pressed %d, want 2 and 1", tapped, longPressed)
+	}
+}
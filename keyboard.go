@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/mevdschee/fyne-mines/clips"
+)
+
+// onKey handles the shortcuts of the game, the arrow keys pan the board and
+// plus and minus zoom, the keys that a tile does not handle end up here
+func (g *game) onKey(ev *clips.Event) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	tile := g.tileSize()
+	switch ev.Key {
+	case fyne.KeyLeft:
+		g.scrollBy(-tile, 0)
+	case fyne.KeyRight:
+		g.scrollBy(tile, 0)
+	case fyne.KeyUp:
+		g.scrollBy(0, -tile)
+	case fyne.KeyDown:
+		g.scrollBy(0, tile)
+	default:
+		switch ev.Rune {
+		case '+', '=':
+			g.zoom(1)
+		case '-':
+			g.zoom(-1)
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// onTileKey plays with the keyboard on the focused tile, space or enter digs,
+// f flags and the arrow keys move the focus to the next tile
+func (g *game) onTileKey(i int, ev *clips.Event) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	x, y := i%g.c.width, i/g.c.width
+	switch ev.Key {
+	case fyne.KeySpace, fyne.KeyReturn, fyne.KeyEnter:
+		if !g.board.IsOver() {
+			g.dig(x, y)
+		}
+	case fyne.KeyLeft:
+		g.focusTile(x-1, y)
+	case fyne.KeyRight:
+		g.focusTile(x+1, y)
+	case fyne.KeyUp:
+		g.focusTile(x, y-1)
+	case fyne.KeyDown:
+		g.focusTile(x, y+1)
+	default:
+		switch ev.Rune {
+		case 'f', 'F':
+			if !g.board.IsOver() {
+				g.toggleMark(x, y)
+			}
+		case ' ':
+			// typed together with the space key that digs
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// focusTile gives a tile the keyboard focus and scrolls it into view
+func (g *game) focusTile(x, y int) {
+	if x < 0 || y < 0 || x >= g.c.width || y >= g.c.height {
+		return
+	}
+	g.getClips("board", "icons")[y*g.c.width+x].Focus()
+	g.scrollToTile(x, y)
+}
@@ -0,0 +1,160 @@
+package layers
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
+	"github.com/mevdschee/fyne-mines/clips"
+)
+
+// binding is a frame expression of a clip that is evaluated again when a
+// value that it uses is set
+type binding struct {
+	clip       *clips.Clip
+	expression string
+	program    *vm.Program
+	index      interface{}
+	names      map[string]bool
+}
+
+// identifiers collects the names of the values that an expression uses, the
+// functions that it calls are left out (calls of builtins are builtin nodes,
+// so a value may have the name of a builtin)
+type identifiers struct {
+	names     map[string]bool
+	functions *Functions
+}
+
+func newIdentifiers(functions *Functions) *identifiers {
+	return &identifiers{names: map[string]bool{}, functions: functions}
+}
+
+func (ids *identifiers) Visit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.IdentifierNode:
+		if !ids.functions.has(n.Value) {
+			ids.names[n.Value] = true
+		}
+	case *ast.CallNode:
+		// the callee is visited before the call
+		if callee, ok := n.Callee.(*ast.IdentifierNode); ok {
+			delete(ids.names, callee.Value)
+		}
+	}
+}
+
+// Bind sets the frame of a clip with an expression that may use the
+// parameters and the values that are set on the layer, the expression may
+// result in a frame index or a frame name, it replaces an earlier binding
+// of the clip, the parameters are added to the env of the layer and only
+// the repeat index "i" is kept for the clip
+func (l *Layer) Bind(clip *clips.Clip, expression string, parameters map[string]interface{}) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.setEnv(parameters)
+	return l.bind(clip, expression, parameters["i"])
+}
+
+func (l *Layer) bind(clip *clips.Clip, expression string, index interface{}) error {
+	program, err := l.functions.compile(expression, kindAny)
+	if err != nil {
+		return err
+	}
+	ids := newIdentifiers(l.functions)
+	node := program.Node()
+	ast.Walk(&node, ids)
+	b := &binding{
+		clip:       clip,
+		expression: expression,
+		program:    program,
+		index:      index,
+		names:      ids.names,
+	}
+	l.unbind(clip)
+	l.bindings = append(l.bindings, b)
+	return l.evaluate(b)
+}
+
+// setEnv adds parameters to the env of the layer, the env is shared by all
+// expressions of the layer
+func (l *Layer) setEnv(parameters map[string]interface{}) {
+	if l.env == nil {
+		l.env = map[string]interface{}{}
+	}
+	for k, v := range parameters {
+		l.env[k] = v
+	}
+}
+
+// Unbind removes the frame binding of a clip
+func (l *Layer) Unbind(clip *clips.Clip) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.unbind(clip)
+}
+
+func (l *Layer) unbind(clip *clips.Clip) {
+	for i, b := range l.bindings {
+		if b.clip == clip {
+			l.bindings = append(l.bindings[:i], l.bindings[i+1:]...)
+			return
+		}
+	}
+}
+
+// Set sets a value that frame expressions may use and updates the frames of
+// the clips that use it
+func (l *Layer) Set(name string, value interface{}) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.env == nil {
+		l.env = map[string]interface{}{}
+	}
+	l.env[name] = value
+	var first error
+	for _, b := range l.bindings {
+		if !b.names[name] {
+			continue
+		}
+		err := l.evaluate(b)
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// evaluate sets the frame of a bound clip, unless the expression uses a
+// value that has not been set yet
+func (l *Layer) evaluate(b *binding) error {
+	if b.index != nil {
+		l.env["i"] = b.index
+	} else {
+		delete(l.env, "i")
+	}
+	for name := range b.names {
+		if _, ok := l.env[name]; !ok {
+			return nil
+		}
+	}
+	value, err := expr.Run(b.program, l.env)
+	if err != nil {
+		return fmt.Errorf("clip '%s': frame in '%s': %v", b.clip.GetName(), b.expression, err)
+	}
+	switch frame := value.(type) {
+	case int:
+		b.clip.GotoFrame(frame, true)
+	case float64:
+		b.clip.GotoFrame(int(frame), true)
+	case string:
+		err = b.clip.GotoFrameByName(frame, true)
+		if err != nil {
+			return fmt.Errorf("clip '%s': frame in '%s': %v", b.clip.GetName(), b.expression, err)
+		}
+	default:
+		return fmt.Errorf("clip '%s': frame in '%s': not a frame index or name: %v", b.clip.GetName(), b.expression, value)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package layers
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+)
+
+// TestBindFunctionCall binds frames to expressions that call functions, the
+// functions are not values that have to be set before they are evaluated
+func TestBindFunctionCall(t *testing.T) {
+	test.NewApp()
+	functions := NewFunctions()
+	functions.Set("double", func(params ...interface{}) (interface{}, error) {
+		return params[0].(int) * 2, nil
+	})
+	layer, err := FromJSON(testSpriteMap(t), testLayerJSON(t, `{"name":"board","clips":[
+		{"sprite":"icons","name":"clamped","x":"0","y":"0","frame":"clamp(v,0,9)"},
+		{"sprite":"icons","name":"doubled","x":"16","y":"0","frame":"double(v)"},
+		{"sprite":"icons","name":"builtin","x":"32","y":"0","frame":"int(max(v, 1))"},
+		{"sprite":"icons","name":"count","x":"48","y":"0","frame":"count"}]}`), map[string]interface{}{"s": 1}, functions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = layer.Set("v", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a value may have the name of a builtin
+	err = layer.Set("count", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]int{"clamped": 5, "doubled": 10, "builtin": 5, "count": 3} {
+		clip := layer.GetClipsByName(name)[0]
+		if frame := clip.GetFrame(); frame != want {
+			t.Errorf("clip '%s' shows frame %d, want %d", name, frame, want)
+		}
+	}
+}
+
+// TestFunctionsPerMovie creates layers with functions of the same name, the
+// functions of one movie do not change the expressions of another
+func TestFunctionsPerMovie(t *testing.T) {
+	test.NewApp()
+	layerJSON := testLayerJSON(t, `{"name":"board","clips":[
+		{"sprite":"icons","name":"icon","x":"pick(0, 16)","y":"0","frame":"pick(1, 2)"}]}`)
+	for i, want := range []int{0, 16} {
+		i := i
+		functions := NewFunctions()
+		functions.Set("pick", func(params ...interface{}) (interface{}, error) {
+			return params[i], nil
+		})
+		layer, err := FromJSON(testSpriteMap(t), layerJSON, map[string]interface{}{"s": 1}, functions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clip := layer.GetClipsByName("icon")[0]
+		if x := clip.GetBounds().Min.X; x != want {
+			t.Errorf("movie %d: clip at x %d, want %d", i, x, want)
+		}
+		if frame := clip.GetFrame(); frame != want/16+1 {
+			t.Errorf("movie %d: clip shows frame %d, want %d", i, frame, want/16+1)
+		}
+	}
+	_, err := FromJSON(testSpriteMap(t), layerJSON, map[string]interface{}{"s": 1}, NewFunctions())
+	if err == nil {
+		t.Error("no error for a function of another movie")
+	}
+}
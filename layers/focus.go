@@ -0,0 +1,79 @@
+package layers
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/mevdschee/fyne-mines/clips"
+)
+
+// SetParent sets where the key events go that the layer does not handle
+func (l *Layer) SetParent(parent clips.KeyHandler) {
+	l.parent = parent
+}
+
+// OnKey sets the handler for the key events that the clips of the layer do
+// not handle, it returns whether it handled the event
+func (l *Layer) OnKey(handler func(ev *clips.Event) bool) {
+	l.onKey = handler
+}
+
+// HandleKey handles a key event that bubbles up from a clip, the tab key
+// moves the focus to the next clip in the tab order of the layer
+func (l *Layer) HandleKey(ev *clips.Event) bool {
+	if l.onKey != nil && l.onKey(ev) {
+		return true
+	}
+	if ev.Key == fyne.KeyTab && l.FocusNext(ev.Clip, ev.Shift) {
+		return true
+	}
+	if l.parent != nil {
+		return l.parent.HandleKey(ev)
+	}
+	return false
+}
+
+// SetTabOrder sets the order in which the clips of the layer get the focus,
+// by default it is the order in which the focusable clips were added
+func (l *Layer) SetTabOrder(order []*clips.Clip) {
+	l.tabOrder = order
+}
+
+// GetTabOrder gets the focusable clips of the layer that are shown in the
+// order in which they get the focus
+func (l *Layer) GetTabOrder() []*clips.Clip {
+	if !l.container.Visible() {
+		return nil
+	}
+	order := l.tabOrder
+	if order == nil {
+		order = l.clips
+	}
+	focusable := []*clips.Clip{}
+	for _, c := range order {
+		if c.IsFocusable() && c.GetContainer().Visible() {
+			focusable = append(focusable, c)
+		}
+	}
+	return focusable
+}
+
+// FocusNext gives the focus to the clip after (or before) a clip in the tab
+// order, it returns false when there is no such clip in the layer
+func (l *Layer) FocusNext(clip *clips.Clip, backwards bool) bool {
+	order := l.GetTabOrder()
+	for i, c := range order {
+		if c != clip {
+			continue
+		}
+		if backwards {
+			i--
+		} else {
+			i++
+		}
+		if i < 0 || i >= len(order) {
+			return false
+		}
+		order[i].Focus()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package layers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
+)
+
+// Function is a function that expressions may call, next to the functions
+// that are built into the expression language (such as min, max and abs)
+type Function func(params ...interface{}) (interface{}, error)
+
+// Functions are the functions that the expressions of a movie may call and
+// the expressions that are compiled with them, every movie has its own
+type Functions struct {
+	mutex    sync.Mutex
+	byName   map[string]Function
+	programs map[programKey]*vm.Program
+}
+
+// NewFunctions creates the functions of a movie, "clamp" and "center" are
+// registered
+func NewFunctions() *Functions {
+	return &Functions{
+		byName: map[string]Function{
+			"clamp":  clamp,
+			"center": center,
+		},
+		programs: map[programKey]*vm.Program{},
+	}
+}
+
+// Set registers a function that expressions may call, it must be registered
+// before the expressions that call it are compiled
+func (f *Functions) Set(name string, function Function) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.byName[name] = function
+	// expressions that were compiled without the function need compiling again
+	f.programs = map[programKey]*vm.Program{}
+}
+
+// options gets the registered functions as compile options
+func (f *Functions) options() []expr.Option {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.compileOptions()
+}
+
+func (f *Functions) compileOptions() []expr.Option {
+	options := []expr.Option{}
+	for name, function := range f.byName {
+		options = append(options, expr.Function(name, function))
+	}
+	return options
+}
+
+// has returns whether a name is a registered function
+func (f *Functions) has(name string) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	_, ok := f.byName[name]
+	return ok
+}
+
+// clamp limits a value to a range, as in clamp(v, min, max)
+func clamp(params ...interface{}) (interface{}, error) {
+	if len(params) != 3 {
+		return nil, fmt.Errorf("clamp expects 3 arguments, got %d", len(params))
+	}
+	numbers, ints, err := toNumbers(params)
+	if err != nil {
+		return nil, fmt.Errorf("clamp: %v", err)
+	}
+	value := numbers[0]
+	if value < numbers[1] {
+		value = numbers[1]
+	}
+	if value > numbers[2] {
+		value = numbers[2]
+	}
+	if ints {
+		return int(value), nil
+	}
+	return value, nil
+}
+
+// center gets the offset that centers a size within another size, as in
+// center(outer, inner)
+func center(params ...interface{}) (interface{}, error) {
+	if len(params) != 2 {
+		return nil, fmt.Errorf("center expects 2 arguments, got %d", len(params))
+	}
+	numbers, ints, err := toNumbers(params)
+	if err != nil {
+		return nil, fmt.Errorf("center: %v", err)
+	}
+	if ints {
+		return (int(numbers[0]) - int(numbers[1])) / 2, nil
+	}
+	return (numbers[0] - numbers[1]) / 2, nil
+}
+
+// toNumbers converts the arguments of a function to floats and reports
+// whether they were all ints
+func toNumbers(params []interface{}) ([]float64, bool, error) {
+	numbers := make([]float64, len(params))
+	ints := true
+	for i, param := range params {
+		switch v := param.(type) {
+		case int:
+			numbers[i] = float64(v)
+		case float64:
+			numbers[i] = v
+			ints = false
+		default:
+			return nil, false, fmt.Errorf("argument %d is not a number: %v", i+1, param)
+		}
+	}
+	return numbers, ints, nil
+}
+
+// EvalVars evaluates expressions that may use the parameters and each other
+// in the order of their dependencies and adds them to the parameters, a
+// cycle is reported as an error
+func (f *Functions) EvalVars(vars map[string]string, parameters map[string]interface{}) error {
+	dependencies := map[string][]string{}
+	names := []string{}
+	for name, expression := range vars {
+		program, err := f.compile(expression, kindAny)
+		if err != nil {
+			return fmt.Errorf("var '%s': '%s': %v", name, expression, err)
+		}
+		ids := newIdentifiers(f)
+		node := program.Node()
+		ast.Walk(&node, ids)
+		for id := range ids.names {
+			if _, ok := vars[id]; ok {
+				dependencies[name] = append(dependencies[name], id)
+			}
+		}
+		sort.Strings(dependencies[name])
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	order := []string{}
+	state := map[string]int{} // 1 is visiting, 2 is done
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case 1:
+			return fmt.Errorf("var '%s': cycle %s -> %s", name, strings.Join(path, " -> "), name)
+		case 2:
+			return nil
+		}
+		state[name] = 1
+		for _, dependency := range dependencies[name] {
+			err := visit(dependency, append(append([]string{}, path...), name))
+			if err != nil {
+				return err
+			}
+		}
+		state[name] = 2
+		order = append(order, name)
+		return nil
+	}
+	for _, name := range names {
+		err := visit(name, nil)
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range order {
+		program, _ := f.compile(vars[name], kindAny)
+		value, err := expr.Run(program, parameters)
+		if err != nil {
+			return fmt.Errorf("var '%s': '%s': %v", name, vars[name], err)
+		}
+		parameters[name] = value
+	}
+	return nil
+}
@@ -2,12 +2,17 @@ package layers
 
 import (
 	"fmt"
+	"image"
+	"sort"
+	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
 	"github.com/mevdschee/fyne-mines/clips"
 	"github.com/mevdschee/fyne-mines/sprites"
+	"golang.org/x/image/draw"
 )
 
 // Layer is a set of layers
@@ -15,12 +20,28 @@ type Layer struct {
 	container *fyne.Container
 	name      string
 	clips     []*clips.Clip
+	raised    []*clips.Clip
+	lowered   []*clips.Clip
+	names     map[string][]*clips.Clip
+	tabOrder  []*clips.Clip
+	onKey     func(ev *clips.Event) bool
+	parent    clips.KeyHandler
+	bindings  []*binding
+	env       map[string]interface{}
+	mutex     sync.Mutex
+	spriteMap sprites.SpriteMap
+	json      LayerJSON
+	instances [][]*clips.Clip
+	opacity   float64
+	functions *Functions
 }
 
 // LayerJSON is a set of layers in JSON
 type LayerJSON struct {
-	Name  string
-	Clips []clips.ClipJSON
+	Name    string
+	Visible string
+	Opacity string
+	Clips   []clips.ClipJSON
 }
 
 // GetName gets the name of the scene
@@ -34,68 +55,111 @@ func New(name string) *Layer {
 		container: container.NewWithoutLayout(),
 		name:      name,
 		clips:     []*clips.Clip{},
+		opacity:   1,
+		functions: NewFunctions(),
 	}
 }
 
-func eval(expression string, parameters map[string]interface{}) (int, error) {
+// the kinds of values that expressions are compiled for
+const (
+	kindInt = iota
+	kindBool
+	kindFloat
+	kindAny
+)
+
+// programKey is an expression string and the kind of value it is compiled for
+type programKey struct {
+	expression string
+	kind       int
+}
+
+// compile compiles an expression for a kind of value with the functions,
+// the programs are cached by expression string and kind
+func (f *Functions) compile(expression string, kind int) (*vm.Program, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	key := programKey{expression, kind}
+	if prog, ok := f.programs[key]; ok {
+		return prog, nil
+	}
+	options := f.compileOptions()
+	switch kind {
+	case kindInt:
+		options = append(options, expr.AsInt())
+	case kindBool:
+		options = append(options, expr.AsBool())
+	case kindFloat:
+		options = append(options, expr.AsFloat64())
+	}
+	prog, err := expr.Compile(expression, options...)
+	if err != nil {
+		return nil, err
+	}
+	f.programs[key] = prog
+	return prog, nil
+}
+
+func (f *Functions) eval(machine *vm.VM, expression string, parameters map[string]interface{}) (int, error) {
 	if len(expression) == 0 {
 		return 0, nil
 	}
-	prog, _ := expr.Compile(expression, expr.AsInt())
-	value, err := expr.Run(prog, parameters)
+	prog, err := f.compile(expression, kindInt)
+	if err != nil {
+		return 0, err
+	}
+	value, err := machine.Run(prog, parameters)
 	if err != nil {
 		return 0, err
 	}
 	return value.(int), nil
 }
 
-// FromJSON creates a new layer from JSON
-func FromJSON(spriteMap sprites.SpriteMap, layerJSON LayerJSON, parameters map[string]interface{}) (*Layer, error) {
+// evalBool evaluates a condition, an empty condition is true
+func (f *Functions) evalBool(machine *vm.VM, expression string, parameters map[string]interface{}) (bool, error) {
+	if len(expression) == 0 {
+		return true, nil
+	}
+	prog, err := f.compile(expression, kindBool)
+	if err != nil {
+		return false, err
+	}
+	value, err := machine.Run(prog, parameters)
+	if err != nil {
+		return false, err
+	}
+	return value.(bool), nil
+}
+
+// evalFloat evaluates an expression that results in a number
+func (f *Functions) evalFloat(machine *vm.VM, expression string, parameters map[string]interface{}) (float64, error) {
+	prog, err := f.compile(expression, kindFloat)
+	if err != nil {
+		return 0, err
+	}
+	value, err := machine.Run(prog, parameters)
+	if err != nil {
+		return 0, err
+	}
+	return value.(float64), nil
+}
+
+// FromJSON creates a new layer from JSON, its expressions may call the
+// functions
+func FromJSON(spriteMap sprites.SpriteMap, layerJSON LayerJSON, parameters map[string]interface{}, functions *Functions) (*Layer, error) {
 	layer := Layer{
 		container: container.NewWithoutLayout(),
 		name:      layerJSON.Name,
 		clips:     []*clips.Clip{},
+		spriteMap: spriteMap,
+		json:      layerJSON,
+		instances: make([][]*clips.Clip, len(layerJSON.Clips)),
+		opacity:   1,
+		functions: functions,
 	}
-	for _, clipJSON := range layerJSON.Clips {
-		sprite, ok := spriteMap[clipJSON.Sprite]
-		if !ok {
-			return nil, fmt.Errorf("could not find sprite '%s' for clip with name '%s'", clipJSON.Sprite, clipJSON.Name)
-		}
-		repeat, err := eval(clipJSON.Repeat, parameters)
-		if err != nil {
-			return nil, fmt.Errorf("repeat in '%s': %v", clipJSON.Repeat, err)
-		}
-		if repeat == 0 {
-			repeat = 1
-		}
-		for i := 0; i < repeat; i++ {
-			parameters["i"] = i
-			x, err := eval(clipJSON.X, parameters)
-			if err != nil {
-				return nil, fmt.Errorf("x in '%s': %v", clipJSON.X, err)
-			}
-			y, err := eval(clipJSON.Y, parameters)
-			if err != nil {
-				return nil, fmt.Errorf("y in '%s': %v", clipJSON.Y, err)
-			}
-			width, err := eval(clipJSON.Width, parameters)
-			if err != nil {
-				return nil, fmt.Errorf("width in '%s': %v", clipJSON.Width, err)
-			}
-			height, err := eval(clipJSON.Height, parameters)
-			if err != nil {
-				return nil, fmt.Errorf("height in '%s': %v", clipJSON.Height, err)
-			}
-			scale, err := eval("s", parameters)
-			if err != nil {
-				return nil, fmt.Errorf("scale in 's': %v", err)
-			}
-			if width == 0 {
-				layer.Add(clips.New(sprite, clipJSON.Name, x, y, scale))
-			} else {
-				layer.Add(clips.NewScaled(sprite, clipJSON.Name, x, y, width, height, scale))
-			}
-		}
+	_, err := layer.SetParameters(parameters)
+	if err != nil {
+		return nil, err
 	}
 	return &layer, nil
 }
@@ -108,22 +172,67 @@ func (l *Layer) GetContainer() *fyne.Container {
 // Add adds a layers to the scene
 func (l *Layer) Add(clip *clips.Clip) {
 	l.clips = append(l.clips, clip)
+	if l.names != nil {
+		l.names[clip.GetName()] = append(l.names[clip.GetName()], clip)
+	}
+	clip.SetParent(l)
+	clip.SetLayerOpacity(l.opacity)
 	container := clip.GetContainer()
 	container.Resize(clip.GetSize())
 	container.Move(clip.GetPosition())
+	if len(l.raised) > 0 {
+		l.restack()
+		return
+	}
 	l.container.Add(container)
 }
 
+// GetClips gets the clips of the layer in the order they are drawn
+func (l *Layer) GetClips() []*clips.Clip {
+	return l.ordered()
+}
+
 // GetClip gets a clip from the layer
 func (l *Layer) GetClip(clip string, i int) (*clips.Clip, error) {
-	n := 0
-	for _, c := range l.clips {
-		if c.GetName() == clip {
-			if n == i {
-				return c, nil
-			}
-			n++
+	named := l.GetClipsByName(clip)
+	if i < 0 || i >= len(named) {
+		return nil, fmt.Errorf("GetClip: clip '%s(%d)' not found", clip, i)
+	}
+	return named[i], nil
+}
+
+// GetClipsByName gets the clips with a name by their repeat index, the slice
+// is shared with the layer and must not be changed
+func (l *Layer) GetClipsByName(clip string) []*clips.Clip {
+	if l.names == nil {
+		l.names = map[string][]*clips.Clip{}
+		for _, c := range l.clips {
+			l.names[c.GetName()] = append(l.names[c.GetName()], c)
 		}
+		for _, named := range l.names {
+			sort.SliceStable(named, func(i, j int) bool {
+				return named[i].GetIndex() < named[j].GetIndex()
+			})
+		}
+	}
+	return l.names[clip]
+}
+
+// GetBounds gets the rectangle that contains all clips (in unscaled pixels)
+func (l *Layer) GetBounds() image.Rectangle {
+	bounds := image.Rectangle{}
+	for _, c := range l.clips {
+		bounds = bounds.Union(c.GetBounds())
+	}
+	return bounds
+}
+
+// Draw draws the visible clips of the layer in order without a GPU
+func (l *Layer) Draw(dst draw.Image) {
+	if !l.container.Visible() {
+		return
+	}
+	for _, c := range l.ordered() {
+		c.Draw(dst)
 	}
-	return nil, fmt.Errorf("GetClip: clip '%s(%d)' not found", clip, i)
 }
@@ -0,0 +1,99 @@
+package layers
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/mevdschee/fyne-mines/sprites"
+)
+
+// testSprites is the sprite meta JSON of the skin of the game
+const testSprites = `[
+	{"name":"icons","x":0,"y":0,"width":16,"height":16,"count":17,"grid":9,"names":[
+		"empty","one","two","three","four","five","six","seven","eight",
+		"closed","opened","bomb","marked","answerNoBomb","answerIsBomb","questionMark","questionPressed"]},
+	{"name":"digits","x":0,"y":33,"width":11,"height":21,"count":11,"gap":1,"names":[
+		"0","1","2","3","4","5","6","7","8","9","minus"]},
+	{"name":"field","x":0,"y":96,"widths":[12,1,12],"heights":[11,1,11],"gap":1}]`
+
+// testBoard is a layer with a board of icons, a field and bound digits
+const testBoard = `{"name":"board","clips":[
+	{"sprite":"field","name":"field","x":"0","y":"44","width":"w*16+24","height":"h*16+22"},
+	{"sprite":"digits","name":"bombs","repeat":"3","x":"17+i*13","y":"16",
+		"frame":"string(int(bombs / [100, 10, 1][i]) % 10)"},
+	{"sprite":"icons","name":"icons","repeat":"w*h","x":"12+(i%w)*16","y":"55+i/w*16"}]}`
+
+func testSpriteMap(tb testing.TB) sprites.SpriteMap {
+	data, err := os.ReadFile("../winxpskin.png")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	spriteMap, err := sprites.NewSpriteMap(data, testSprites)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return spriteMap
+}
+
+func testLayerJSON(tb testing.TB, data string) LayerJSON {
+	layerJSON := LayerJSON{}
+	err := json.Unmarshal([]byte(data), &layerJSON)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return layerJSON
+}
+
+func TestFromJSON(t *testing.T) {
+	test.NewApp()
+	layer, err := FromJSON(testSpriteMap(t), testLayerJSON(t, testBoard), map[string]interface{}{"w": 9, "h": 9, "s": 1}, NewFunctions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(layer.GetClipsByName("icons")); n != 81 {
+		t.Errorf("got %d icons, want 81", n)
+	}
+	err = layer.Set("bombs", 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []int{0, 4, 2} {
+		if frame := layer.GetClipsByName("bombs")[i].GetFrame(); frame != want {
+			t.Errorf("digit %d on frame %d, want %d", i, frame, want)
+		}
+	}
+}
+
+func BenchmarkFromJSON(b *testing.B) {
+	test.NewApp()
+	spriteMap := testSpriteMap(b)
+	layerJSON := testLayerJSON(b, testBoard)
+	parameters := map[string]interface{}{"w": 100, "h": 100, "s": 1}
+	functions := NewFunctions()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, err := FromJSON(spriteMap, layerJSON, parameters, functions)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSet(b *testing.B) {
+	test.NewApp()
+	layer, err := FromJSON(testSpriteMap(b), testLayerJSON(b, testBoard), map[string]interface{}{"w": 30, "h": 16, "s": 1}, NewFunctions())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		err = layer.Set("bombs", n%1000)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package layers
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2/theme"
+	"github.com/expr-lang/expr/vm"
+	"github.com/mevdschee/fyne-mines/clips"
+	"github.com/mevdschee/fyne-mines/sprites"
+)
+
+// layout is the place and size of a clip instance (in unscaled pixels)
+type layout struct {
+	x, y          int
+	width, height int
+	columns, rows int
+	size          int
+}
+
+// SetParameters evaluates the expressions of the clips from JSON again with
+// other parameters, the clips are moved and resized and instances are added
+// or removed when the repeat or the condition changes, the clips that were
+// added are returned, the expressions are evaluated in the env of the layer
+func (l *Layer) SetParameters(parameters map[string]interface{}) ([]*clips.Clip, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.setEnv(parameters)
+	env := l.env
+	machine := &vm.VM{}
+	scale, err := l.functions.eval(machine, "s", env)
+	if err != nil {
+		return nil, fmt.Errorf("layer '%s': scale in 's': %v", l.name, err)
+	}
+	if l.json.Visible != "" {
+		visible, err := l.functions.evalBool(machine, l.json.Visible, env)
+		if err != nil {
+			return nil, fmt.Errorf("layer '%s': visible in '%s': %v", l.name, l.json.Visible, err)
+		}
+		l.SetVisible(visible)
+	}
+	if l.json.Opacity != "" {
+		opacity, err := l.functions.evalFloat(machine, l.json.Opacity, env)
+		if err != nil {
+			return nil, fmt.Errorf("layer '%s': opacity in '%s': %v", l.name, l.json.Opacity, err)
+		}
+		l.SetOpacity(opacity)
+	}
+	added := []*clips.Clip{}
+	changed := false
+	// pos is where the next clip from JSON goes in the order of JSON
+	pos := 0
+	for j, clipJSON := range l.json.Clips {
+		sprite, ok := l.spriteMap[clipJSON.Sprite]
+		if !ok && !isFontText(clipJSON) {
+			return nil, fmt.Errorf("could not find sprite '%s' for clip with name '%s'", clipJSON.Sprite, clipJSON.Name)
+		}
+		repeat, err := l.functions.eval(machine, clipJSON.Repeat, env)
+		if err != nil {
+			return nil, fmt.Errorf("clip '%s': repeat in '%s': %v", clipJSON.Name, clipJSON.Repeat, err)
+		}
+		if repeat == 0 {
+			repeat = 1
+		}
+		instances := make([]*clips.Clip, repeat)
+		for i, clip := range l.instances[j] {
+			if i < repeat {
+				instances[i] = clip
+			} else if clip != nil {
+				l.remove(clip, pos)
+				changed = true
+			}
+		}
+		for i := 0; i < repeat; i++ {
+			clip := instances[i]
+			env["i"] = i
+			show, err := l.functions.evalBool(machine, clipJSON.If, env)
+			if err != nil {
+				return nil, fmt.Errorf("clip '%s': if in '%s': %v", clipJSON.Name, clipJSON.If, err)
+			}
+			if !show {
+				if clip != nil {
+					l.remove(clip, pos)
+					instances[i] = nil
+					changed = true
+				}
+				continue
+			}
+			place, err := l.evalLayout(machine, clipJSON, env)
+			if err != nil {
+				return nil, err
+			}
+			text := clipJSON.Text
+			if clip != nil && !fits(clip, clipJSON, place) {
+				if clip.IsText() {
+					text = clip.GetText()
+				}
+				l.remove(clip, pos)
+				clip = nil
+			}
+			if clip == nil {
+				clip, err = newClip(sprite, clipJSON, place, scale)
+				if err != nil {
+					return nil, fmt.Errorf("clip '%s': %v", clipJSON.Name, err)
+				}
+				clip.SetText(text)
+				clip.SetIndex(i)
+				clip.SetActions(clipJSON.On)
+				clip.SetFocusable(clipJSON.Focusable)
+				l.insert(clip, pos)
+				instances[i] = clip
+				added = append(added, clip)
+				changed = true
+				pos++
+			} else {
+				if clip.GetScale() != scale {
+					clip.SetScale(scale)
+				}
+				clip.Move(place.x, place.y)
+				if clip.IsScaled() {
+					clip.ResizeScaled(place.width, place.height)
+				}
+				pos = l.indexOf(clip, pos) + 1
+			}
+			if clipJSON.Visible != "" {
+				visible, err := l.functions.evalBool(machine, clipJSON.Visible, env)
+				if err != nil {
+					return nil, fmt.Errorf("clip '%s': visible in '%s': %v", clipJSON.Name, clipJSON.Visible, err)
+				}
+				clip.SetVisible(visible)
+			}
+			if clipJSON.Opacity != "" {
+				opacity, err := l.functions.evalFloat(machine, clipJSON.Opacity, env)
+				if err != nil {
+					return nil, fmt.Errorf("clip '%s': opacity in '%s': %v", clipJSON.Name, clipJSON.Opacity, err)
+				}
+				clip.SetOpacity(opacity)
+			}
+			if clipJSON.Frame != "" {
+				err = l.bind(clip, clipJSON.Frame, i)
+				if err != nil {
+					return nil, fmt.Errorf("clip '%s': frame in '%s': %v", clipJSON.Name, clipJSON.Frame, err)
+				}
+			}
+		}
+		l.instances[j] = instances
+	}
+	for _, clip := range l.clips[pos:] {
+		if clip.GetScale() != scale {
+			clip.SetScale(scale)
+		}
+	}
+	if changed {
+		l.restack()
+	}
+	return added, nil
+}
+
+// evalLayout evaluates the place and size of a clip instance
+func (l *Layer) evalLayout(machine *vm.VM, clipJSON clips.ClipJSON, env map[string]interface{}) (layout, error) {
+	place := layout{}
+	fields := []struct {
+		name       string
+		expression string
+		value      *int
+	}{
+		{"x", clipJSON.X, &place.x},
+		{"y", clipJSON.Y, &place.y},
+		{"width", clipJSON.Width, &place.width},
+		{"height", clipJSON.Height, &place.height},
+		{"columns", clipJSON.Columns, &place.columns},
+		{"rows", clipJSON.Rows, &place.rows},
+		{"size", clipJSON.Size, &place.size},
+	}
+	for _, field := range fields {
+		value, err := l.functions.eval(machine, field.expression, env)
+		if err != nil {
+			return place, fmt.Errorf("clip '%s': %s in '%s': %v", clipJSON.Name, field.name, field.expression, err)
+		}
+		*field.value = value
+	}
+	if isFontText(clipJSON) && place.size == 0 {
+		place.size = int(theme.TextSize())
+	}
+	return place, nil
+}
+
+// newClip creates the clip of a type that the JSON asks for
+func newClip(sprite *sprites.Sprite, clipJSON clips.ClipJSON, place layout, scale int) (*clips.Clip, error) {
+	switch clipJSON.Type {
+	case clips.TypeTilemap:
+		return clips.NewTilemap(sprite, clipJSON.Name, place.x, place.y, place.columns, place.rows, scale), nil
+	case clips.TypeText:
+		align, err := clips.ParseAlign(clipJSON.Align)
+		if err != nil {
+			return nil, err
+		}
+		if clipJSON.Font == "" {
+			return clips.NewBitmapText(sprite, clipJSON.Name, place.x, place.y, place.width, clipJSON.Text, align, scale), nil
+		}
+		style, err := clips.ParseFont(clipJSON.Font)
+		if err != nil {
+			return nil, err
+		}
+		col, err := clips.ParseColor(clipJSON.Color)
+		if err != nil {
+			return nil, err
+		}
+		return clips.NewText(clipJSON.Name, place.x, place.y, place.width, clipJSON.Text, place.size, col, style, align, scale), nil
+	}
+	if place.width == 0 {
+		return clips.New(sprite, clipJSON.Name, place.x, place.y, scale), nil
+	}
+	return clips.NewScaled(sprite, clipJSON.Name, place.x, place.y, place.width, place.height, scale), nil
+}
+
+// isFontText returns whether a clip in JSON is a text in a font, it has no
+// sprite
+func isFontText(clipJSON clips.ClipJSON) bool {
+	return clipJSON.Type == clips.TypeText && clipJSON.Font != ""
+}
+
+// fits returns whether a clip can be moved and resized to a place, a clip
+// of another type, a tilemap with another grid or a text with another
+// width or size has to be created again
+func fits(clip *clips.Clip, clipJSON clips.ClipJSON, place layout) bool {
+	switch clipJSON.Type {
+	case clips.TypeTilemap:
+		columns, rows := clip.GetGrid()
+		return columns == place.columns && rows == place.rows
+	case clips.TypeText:
+		size := 0
+		if isFontText(clipJSON) {
+			size = place.size
+		}
+		return clip.FitsText(place.width, size)
+	}
+	return clip.GetTileCount() == 0 && !clip.IsText() && clip.IsScaled() == (place.width != 0)
+}
+
+// indexOf finds a clip in the drawing order from a position on
+func (l *Layer) indexOf(clip *clips.Clip, from int) int {
+	for i := from; i < len(l.clips); i++ {
+		if l.clips[i] == clip {
+			return i
+		}
+	}
+	for i := 0; i < from && i < len(l.clips); i++ {
+		if l.clips[i] == clip {
+			return i
+		}
+	}
+	return -1
+}
+
+// insert adds a clip to the layer at a position in the order of JSON, the
+// containers are put in the drawing order afterwards
+func (l *Layer) insert(clip *clips.Clip, pos int) {
+	if pos >= len(l.clips) {
+		l.Add(clip)
+		return
+	}
+	clip.SetParent(l)
+	clip.SetLayerOpacity(l.opacity)
+	container := clip.GetContainer()
+	container.Resize(clip.GetSize())
+	container.Move(clip.GetPosition())
+	l.clips = append(l.clips[:pos], append([]*clips.Clip{clip}, l.clips[pos:]...)...)
+	l.names = nil
+}
+
+// remove takes a clip out of the layer, its frame binding and the tab order,
+// the search for it starts at a position in the order of JSON, the
+// containers are put in the drawing order afterwards, the lock of the layer
+// is held
+func (l *Layer) remove(clip *clips.Clip, from int) {
+	i := l.indexOf(clip, from)
+	if i < 0 {
+		return
+	}
+	l.clips = append(l.clips[:i], l.clips[i+1:]...)
+	l.names = nil
+	l.raised = without(l.raised, clip)
+	l.lowered = without(l.lowered, clip)
+	l.unbind(clip)
+	if l.tabOrder != nil {
+		order := []*clips.Clip{}
+		for _, c := range l.tabOrder {
+			if c != clip {
+				order = append(order, c)
+			}
+		}
+		l.tabOrder = order
+	}
+}
@@ -0,0 +1,237 @@
+package layers
+
+import (
+	"fmt"
+	"image"
+	"sort"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/mevdschee/fyne-mines/clips"
+	"github.com/mevdschee/fyne-mines/sprites"
+)
+
+// Validate checks a layer in JSON and reports every problem with its JSON
+// path, clips are checked against the bounds unless these are empty
+func Validate(spriteMap sprites.SpriteMap, layerJSON LayerJSON, parameters map[string]interface{}, functions *Functions, bounds image.Rectangle, path string) []error {
+	errs := []error{}
+	if layerJSON.Name == "" {
+		errs = append(errs, fmt.Errorf("%s.name: layer has no name", path))
+	}
+	env := map[string]interface{}{"i": 0}
+	for name, value := range parameters {
+		env[name] = value
+	}
+	if layerJSON.Visible != "" {
+		_, err := expr.Compile(layerJSON.Visible, append(functions.options(), expr.Env(env), expr.AsBool())...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.visible: '%s': %v", path, layerJSON.Visible, err))
+		}
+	}
+	if layerJSON.Opacity != "" {
+		_, err := expr.Compile(layerJSON.Opacity, append(functions.options(), expr.Env(env), expr.AsFloat64())...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.opacity: '%s': %v", path, layerJSON.Opacity, err))
+		}
+	}
+	for j, clipJSON := range layerJSON.Clips {
+		errs = append(errs, validateClip(spriteMap, clipJSON, env, functions, bounds, fmt.Sprintf("%s.clips[%d]", path, j))...)
+	}
+	return errs
+}
+
+func validateClip(spriteMap sprites.SpriteMap, clipJSON clips.ClipJSON, env map[string]interface{}, functions *Functions, bounds image.Rectangle, path string) []error {
+	errs := []error{}
+	label := fmt.Sprintf("clip '%s'", clipJSON.Name)
+	if clipJSON.Name == "" {
+		label = fmt.Sprintf("clip with sprite '%s'", clipJSON.Sprite)
+	}
+	sprite, ok := spriteMap[clipJSON.Sprite]
+	if !ok && !isFontText(clipJSON) {
+		errs = append(errs, fmt.Errorf("%s.sprite: could not find sprite '%s'", path, clipJSON.Sprite))
+	}
+	fields := []struct {
+		name       string
+		expression string
+	}{
+		{"repeat", clipJSON.Repeat},
+		{"x", clipJSON.X},
+		{"y", clipJSON.Y},
+		{"width", clipJSON.Width},
+		{"height", clipJSON.Height},
+		{"columns", clipJSON.Columns},
+		{"rows", clipJSON.Rows},
+		{"size", clipJSON.Size},
+	}
+	valid := true
+	for _, field := range fields {
+		if field.expression == "" {
+			continue
+		}
+		_, err := expr.Compile(field.expression, append(functions.options(), expr.Env(env), expr.AsInt())...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.%s: '%s': %v", path, field.name, field.expression, err))
+			valid = false
+		}
+	}
+	if clipJSON.If != "" {
+		_, err := expr.Compile(clipJSON.If, append(functions.options(), expr.Env(env), expr.AsBool())...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.if: '%s': %v", path, clipJSON.If, err))
+			valid = false
+		}
+	}
+	if clipJSON.Visible != "" {
+		_, err := expr.Compile(clipJSON.Visible, append(functions.options(), expr.Env(env), expr.AsBool())...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.visible: '%s': %v", path, clipJSON.Visible, err))
+		}
+	}
+	if clipJSON.Opacity != "" {
+		_, err := expr.Compile(clipJSON.Opacity, append(functions.options(), expr.Env(env), expr.AsFloat64())...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.opacity: '%s': %v", path, clipJSON.Opacity, err))
+		}
+	}
+	if clipJSON.Frame != "" {
+		// the values that frames are bound to are only known at runtime
+		_, err := expr.Compile(clipJSON.Frame, functions.options()...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.frame: '%s': %v", path, clipJSON.Frame, err))
+		}
+	}
+	tilemap := clipJSON.Type == clips.TypeTilemap
+	text := clipJSON.Type == clips.TypeText
+	if clipJSON.Type != "" && !tilemap && !text {
+		errs = append(errs, fmt.Errorf("%s.type: unknown clip type '%s'", path, clipJSON.Type))
+		valid = false
+	}
+	if text {
+		errs = append(errs, validateText(clipJSON, path)...)
+	} else if clipJSON.Text != "" || clipJSON.Font != "" {
+		errs = append(errs, fmt.Errorf("%s: %s must have type '%s' to have text or a font", path, label, clips.TypeText))
+	}
+	if tilemap && (clipJSON.Columns == "" || clipJSON.Rows == "") {
+		errs = append(errs, fmt.Errorf("%s: %s must have columns and rows", path, label))
+		valid = false
+	}
+	if (clipJSON.Width == "") != (clipJSON.Height == "") && !text {
+		errs = append(errs, fmt.Errorf("%s: %s must have both width and height or neither", path, label))
+		valid = false
+	}
+	events := []string{}
+	for event := range clipJSON.On {
+		events = append(events, event)
+	}
+	sort.Strings(events)
+	for _, event := range events {
+		if !isEvent(event) {
+			errs = append(errs, fmt.Errorf("%s.on.%s: unknown event '%s'", path, event, event))
+		} else if clipJSON.On[event] == "" {
+			errs = append(errs, fmt.Errorf("%s.on.%s: action must not be empty", path, event))
+		}
+	}
+	if !ok || !valid || text {
+		return errs
+	}
+	scaled := clipJSON.Width != "" && !tilemap
+	if scaled && !sprite.IsSliced() {
+		return append(errs, fmt.Errorf("%s.width: sprite '%s' has no nine-slice widths and heights", path, sprite.Name))
+	}
+	machine := &vm.VM{}
+	repeat, err := functions.eval(machine, clipJSON.Repeat, env)
+	if err != nil {
+		return append(errs, fmt.Errorf("%s.repeat: '%s': %v", path, clipJSON.Repeat, err))
+	}
+	if repeat == 0 {
+		repeat = 1
+	}
+	outside, tooSmall := []int{}, []int{}
+	var first, smallest image.Rectangle
+	for i := 0; i < repeat; i++ {
+		env["i"] = i
+		show, err := functions.evalBool(machine, clipJSON.If, env)
+		if err != nil {
+			return append(errs, fmt.Errorf("%s.if: '%s' (i=%d): %v", path, clipJSON.If, i, err))
+		}
+		if !show {
+			continue
+		}
+		values := [6]int{}
+		for k, expression := range []string{clipJSON.X, clipJSON.Y, clipJSON.Width, clipJSON.Height, clipJSON.Columns, clipJSON.Rows} {
+			values[k], err = functions.eval(machine, expression, env)
+			if err != nil {
+				return append(errs, fmt.Errorf("%s.%s: '%s' (i=%d): %v", path, fields[k+1].name, expression, i, err))
+			}
+		}
+		width, height := sprite.Width, sprite.Height
+		if scaled {
+			width, height = values[2], values[3]
+		}
+		if tilemap {
+			width, height = values[4]*sprite.Width, values[5]*sprite.Height
+		}
+		rect := image.Rect(values[0], values[1], values[0]+width, values[1]+height)
+		if scaled && (width < sprite.Widths[0]+sprite.Widths[2] || height < sprite.Heights[0]+sprite.Heights[2]) {
+			if len(tooSmall) == 0 {
+				smallest = rect
+			}
+			tooSmall = append(tooSmall, i)
+		}
+		if !bounds.Empty() && !rect.In(bounds) {
+			if len(outside) == 0 {
+				first = rect
+			}
+			outside = append(outside, i)
+		}
+	}
+	env["i"] = 0
+	if len(tooSmall) > 0 {
+		errs = append(errs, fmt.Errorf("%s: %s (i=%d) of %dx%d is smaller than the corners of sprite '%s' (%dx%d)%s", path, label, tooSmall[0],
+			smallest.Dx(), smallest.Dy(), sprite.Name, sprite.Widths[0]+sprite.Widths[2], sprite.Heights[0]+sprite.Heights[2], more(tooSmall)))
+	}
+	if len(outside) > 0 {
+		errs = append(errs, fmt.Errorf("%s: %s (i=%d) at %v is outside the canvas %v%s", path, label, outside[0], first, bounds, more(outside)))
+	}
+	return errs
+}
+
+// validateText checks the alignment, the font and the color of a text clip
+func validateText(clipJSON clips.ClipJSON, path string) []error {
+	errs := []error{}
+	_, err := clips.ParseAlign(clipJSON.Align)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s.align: %v", path, err))
+	}
+	if clipJSON.Font == "" {
+		if clipJSON.Size != "" || clipJSON.Color != "" {
+			errs = append(errs, fmt.Errorf("%s: a text with glyphs from a sprite has no size or color, these need a font", path))
+		}
+		return errs
+	}
+	_, err = clips.ParseFont(clipJSON.Font)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s.font: %v", path, err))
+	}
+	_, err = clips.ParseColor(clipJSON.Color)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s.color: %v", path, err))
+	}
+	return errs
+}
+
+func more(instances []int) string {
+	if len(instances) < 2 {
+		return ""
+	}
+	return fmt.Sprintf(" (and %d more)", len(instances)-1)
+}
+
+func isEvent(name string) bool {
+	for _, event := range clips.Events {
+		if event == name {
+			return true
+		}
+	}
+	return false
+}
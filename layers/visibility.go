@@ -0,0 +1,132 @@
+package layers
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"github.com/mevdschee/fyne-mines/clips"
+)
+
+// Stacker keeps layers in the order they are drawn, a scene is one
+type Stacker interface {
+	MoveLayerToTop(layer *Layer)
+	MoveLayerToBottom(layer *Layer)
+}
+
+// IsVisible returns whether the layer is shown
+func (l *Layer) IsVisible() bool {
+	return l.container.Visible()
+}
+
+// SetVisible shows or hides the layer, the clips of a hidden layer get no
+// events and are not drawn
+func (l *Layer) SetVisible(visible bool) {
+	if l.container.Visible() == visible {
+		return
+	}
+	if visible {
+		l.container.Show()
+	} else {
+		l.container.Hide()
+	}
+}
+
+// GetOpacity gets the opacity of the layer
+func (l *Layer) GetOpacity() float64 {
+	return l.opacity
+}
+
+// SetOpacity sets the opacity of the layer (from 0 to 1), it is multiplied
+// with the opacity of each clip
+func (l *Layer) SetOpacity(opacity float64) {
+	opacity = math.Max(0, math.Min(1, opacity))
+	if l.opacity == opacity {
+		return
+	}
+	l.opacity = opacity
+	for _, c := range l.clips {
+		c.SetLayerOpacity(opacity)
+	}
+}
+
+// MoveToTop draws the layer above the other layers of its scene
+func (l *Layer) MoveToTop() {
+	if s, ok := l.parent.(Stacker); ok {
+		s.MoveLayerToTop(l)
+	}
+}
+
+// MoveToBottom draws the layer below the other layers of its scene
+func (l *Layer) MoveToBottom() {
+	if s, ok := l.parent.(Stacker); ok {
+		s.MoveLayerToBottom(l)
+	}
+}
+
+// MoveClipToTop draws a clip of the layer above the others, the clips that
+// are not moved keep the order of JSON
+func (l *Layer) MoveClipToTop(clip *clips.Clip) {
+	if l.indexOf(clip, 0) < 0 {
+		return
+	}
+	l.lowered = without(l.lowered, clip)
+	l.raised = append(without(l.raised, clip), clip)
+	l.restack()
+}
+
+// MoveClipToBottom draws a clip of the layer below the others
+func (l *Layer) MoveClipToBottom(clip *clips.Clip) {
+	if l.indexOf(clip, 0) < 0 {
+		return
+	}
+	l.raised = without(l.raised, clip)
+	l.lowered = append(without(l.lowered, clip), clip)
+	l.restack()
+}
+
+// ordered gets the clips in the order they are drawn, the clips that were
+// moved to the bottom, then the others in the order of JSON and then the
+// clips that were moved to the top, the last moved is drawn outermost
+func (l *Layer) ordered() []*clips.Clip {
+	if len(l.raised) == 0 && len(l.lowered) == 0 {
+		return l.clips
+	}
+	moved := map[*clips.Clip]bool{}
+	for _, c := range l.raised {
+		moved[c] = true
+	}
+	for _, c := range l.lowered {
+		moved[c] = true
+	}
+	order := make([]*clips.Clip, 0, len(l.clips))
+	for i := len(l.lowered) - 1; i >= 0; i-- {
+		order = append(order, l.lowered[i])
+	}
+	for _, c := range l.clips {
+		if !moved[c] {
+			order = append(order, c)
+		}
+	}
+	return append(order, l.raised...)
+}
+
+// restack puts the containers of the clips in the order they are drawn
+func (l *Layer) restack() {
+	objects := make([]fyne.CanvasObject, 0, len(l.clips))
+	for _, c := range l.ordered() {
+		objects = append(objects, c.GetContainer())
+	}
+	l.container.Objects = objects
+	clips.RefreshContainer(l.container)
+}
+
+// without gets a list of clips without a clip
+func without(list []*clips.Clip, clip *clips.Clip) []*clips.Clip {
+	result := []*clips.Clip{}
+	for _, c := range list {
+		if c != clip {
+			result = append(result, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package layers
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+)
+
+// TestMoveClipThenSetParameters moves clips to the top and the bottom and
+// then adds and removes clips, the moved clips keep their place in the
+// drawing order and the others stay in the order of JSON
+func TestMoveClipThenSetParameters(t *testing.T) {
+	test.NewApp()
+	layer, err := FromJSON(testSpriteMap(t), testLayerJSON(t, `{"name":"board","clips":[
+		{"sprite":"icons","name":"a","repeat":"n","x":"i*16","y":"0"},
+		{"sprite":"icons","name":"b","repeat":"n","x":"i*16","y":"16"},
+		{"sprite":"icons","name":"c","x":"0","y":"32"}]}`), map[string]interface{}{"n": 2, "s": 1}, NewFunctions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	layer.MoveClipToTop(layer.GetClipsByName("a")[1])
+	layer.MoveClipToBottom(layer.GetClipsByName("c")[0])
+	checkOrder(t, layer, "c0 a0 b0 b1 a1")
+	_, err = layer.SetParameters(map[string]interface{}{"n": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOrder(t, layer, "c0 a0 a2 b0 b1 b2 a1")
+	_, err = layer.SetParameters(map[string]interface{}{"n": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOrder(t, layer, "c0 a0 b0")
+	layer.MoveClipToTop(layer.GetClipsByName("a")[0])
+	checkOrder(t, layer, "c0 b0 a0")
+}
+
+// checkOrder checks the drawing order of the clips and of their containers
+func checkOrder(t *testing.T, layer *Layer, want string) {
+	t.Helper()
+	got := ""
+	for i, clip := range layer.GetClips() {
+		if i > 0 {
+			got += " "
+		}
+		got += clip.GetName() + string(rune('0'+clip.GetIndex()))
+		if layer.GetContainer().Objects[i] != clip.GetContainer() {
+			t.Errorf("container of clip %d is not in the drawing order", i)
+		}
+	}
+	if len(layer.GetContainer().Objects) != len(layer.GetClips()) {
+		t.Errorf("layer has %d objects for %d clips", len(layer.GetContainer().Objects), len(layer.GetClips()))
+	}
+	if got != want {
+		t.Errorf("drawn in the order %s, want %s", got, want)
+	}
+}
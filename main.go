@@ -1,38 +1,35 @@
 package main
 
 import (
+	_ "embed"
+	"flag"
 	"log"
 	"math/rand"
+	"os"
+	"sync"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"github.com/mevdschee/fyne-mines/boards"
 	"github.com/mevdschee/fyne-mines/clips"
+	"github.com/mevdschee/fyne-mines/layers"
 	"github.com/mevdschee/fyne-mines/movies"
 	"github.com/mevdschee/fyne-mines/sprites"
 )
 
-const spriteMapMeta = `
-	[{"name":"display","x":28,"y":82,"width":41,"height":25,"count":1},
-	{"name":"icons","x":0,"y":0,"width":16,"height":16,"count":17,"grid":9},
-	{"name":"digits","x":0,"y":33,"width":11,"height":21,"count":11,"gap":1},
-	{"name":"buttons","x":0,"y":55,"width":26,"height":26,"count":5,"gap":1},
-	{"name":"controls","x":0,"y":82,"widths":[12,1,12],"heights":[11,1,11],"gap":1},
-	{"name":"field","x":0,"y":96,"widths":[12,1,12],"heights":[11,1,11],"gap":1}]`
-
-const movieScenes = `
-	[{"name":"game","layers":[{"name":"bg","clips":[
-		{"sprite":"controls","x":"0","y":"0","width":"w*16+24","height":"55"},
-		{"sprite":"field","x":"0","y":"44","width":"w*16+24","height":"h*16+22"},
-		{"sprite":"display","x":"16","y":"15"},
-		{"sprite":"display","x":"w*16-33","y":"15"}
-	]},{"name":"fg","clips":[
-		{"sprite":"digits","name":"bombs","repeat":"3","x":"18+i*13","y":"17"},
-		{"sprite":"digits","name":"time","repeat":"3","x":"w*16-31+i*13","y":"17"},
-		{"sprite":"buttons","name":"button","x":"(w*16)/2-1","y":"15"},
-		{"sprite":"icons","name":"icons","repeat":"w*h","x":"12+(i%w)*16","y":"55+floor(i/w)*16"}
-	]}]}]`
+// spriteMapMeta is the built-in sprite meta JSON of the skin
+//
+//go:embed skin/sprites.json
+var spriteMapMeta string
+
+// movieScenes is the built-in movie JSON of the game
+//
+//go:embed skin/movie.json
+var movieScenes string
 
 type config struct {
 	scale   int
@@ -40,63 +37,30 @@ type config struct {
 	height  int
 	bombs   int
 	holding int
+	touch   bool
+	hover   bool
 }
 
 type game struct {
-	c      config
-	movie  *movies.Movie
-	button int
-	bombs  int
-	closed int
-	state  int
-	time   int64
-	tiles  [][]tile
-}
-
-type tile struct {
-	open    bool
-	marked  bool
-	bomb    bool
-	pressed bool
-	number  int
-}
-
-const (
-	stateWaiting = iota
-	statePlaying
-	stateWon
-	stateLost
-)
-
-const (
-	buttonPlaying = iota
-	buttonEvaluate
-	buttonLost
-	buttonWon
-	buttonPressed
-)
-
-const (
-	iconEmpty = iota
-	iconNumberOne
-	iconNumberTwo
-	iconNumberThree
-	iconNumberFour
-	iconNumberFive
-	iconNumberSix
-	iconNumberSeven
-	iconNumberEight
-	iconClosed
-	iconOpened
-	iconBomb
-	iconMarked
-	iconAnswerNoBomb
-	iconAnswerIsBomb
-	iconQuestionMark
-	iconQuestionPressed
-)
-
-var clipCache map[string][]*clips.Clip
+	mutex     sync.Mutex
+	c         config
+	window    fyne.Window
+	devError  error
+	movie     *movies.Movie
+	scroll    *container.Scroll
+	viewport  *fixedLayout
+	panning   bool
+	flagMode  bool
+	flagged   map[int]bool
+	hoverX    int
+	hoverY    int
+	hovered   map[int]bool
+	button    string
+	seconds   int
+	time      int64
+	board     *boards.Board
+	recording recording
+}
 
 func (g *game) getSize() (int, int) {
 	return g.c.scale * (g.c.width*16 + 12*2), g.c.scale * (g.c.height*16 + 11*3 + 33)
@@ -106,386 +70,429 @@ func (g *game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeigh
 	return g.getSize()
 }
 
-func (g *game) init() {
-	spriteMap, err := sprites.NewSpriteMap(resourceWinxpskinPng.Content(), spriteMapMeta)
+func (g *game) init() error {
+	meta, scenes, err := readSources()
+	if err != nil {
+		return err
+	}
+	movie, err := g.loadMovie(meta, scenes)
+	if err != nil {
+		return err
+	}
+	g.movie = movie
+	return nil
+}
+
+func (g *game) loadMovie(meta, scenes string) (*movies.Movie, error) {
+	spriteMap, err := sprites.NewSpriteMap(resourceWinxpskinPng.Content(), meta)
 	if err != nil {
-		log.Fatalln(err)
+		return nil, err
 	}
-	parameters := map[string]interface{}{
+	movie, err := movies.FromJSON(spriteMap, scenes, g.parameters(), layers.NewFunctions())
+	if err != nil {
+		return nil, err
+	}
+	return movie, nil
+}
+
+// parameters are the values that the expressions in the movie may use
+func (g *game) parameters() map[string]interface{} {
+	return map[string]interface{}{
 		"w": g.c.width,
 		"h": g.c.height,
 		"s": g.c.scale,
 	}
-	movie, err := movies.FromJSON(spriteMap, movieScenes, parameters)
+}
+
+// setParameters lays out the movie again for the size and the scale in the
+// config, only the clips that are new or no longer needed are changed
+func (g *game) setParameters() error {
+	g.hover(-1, -1)
+	added, err := g.movie.SetParameters(g.parameters())
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
-	width, height := g.getSize()
-	movie.SetSize(width, height)
-	g.movie = movie
-	clipCache = map[string][]*clips.Clip{}
+	icons := []*clips.Clip{}
+	for _, clip := range added {
+		if clip.GetName() == "icons" {
+			icons = append(icons, clip)
+		}
+	}
+	g.setTileHandlers(icons)
+	g.layoutContent()
+	g.window.Content().Refresh()
+	g.window.Resize(fyne.NewSize(0, 0))
+	return nil
 }
 
-func (g *game) getClips(clip string) []*clips.Clip {
-	if clipCache == nil {
-		clipCache = map[string][]*clips.Clip{}
-	}
-	cache, ok := clipCache[clip]
-	if ok {
-		return cache
+// setDifficulty starts a new game on a board of another size without
+// creating the movie and the window content again
+func (g *game) setDifficulty(width, height, bombs int) error {
+	g.c.width, g.c.height, g.c.bombs = width, height, bombs
+	err := g.setParameters()
+	if err != nil {
+		return err
 	}
-	clips, err := g.movie.GetClips("game", "fg", clip)
+	g.flagged = nil
+	g.restart()
+	return nil
+}
+
+func (g *game) getClips(layer, clip string) []*clips.Clip {
+	clips, err := g.movie.GetClips("game", layer, clip)
 	if err != nil {
 		log.Fatal(err)
 	}
-	clipCache[clip] = clips
 	return clips
 }
 
+// locked runs a handler with the lock of the game held, the handlers of the
+// UI, the timer and the reload in dev mode run on different goroutines
+func (g *game) locked(handler func()) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	handler()
+}
+
+// onAction binds an action of the movie that runs with the lock held
+func (g *game) onAction(name string, action movies.Action) {
+	g.movie.OnAction(name, func(clip *clips.Clip, i int, ev *clips.Event) {
+		g.locked(func() {
+			action(clip, i, ev)
+		})
+	})
+}
+
+// handleKey passes a key event of the canvas to the movie
+func (g *game) handleKey(ev *clips.Event) {
+	g.mutex.Lock()
+	movie := g.movie
+	g.mutex.Unlock()
+	movie.HandleKey(ev)
+}
+
 func (g *game) setHandlers() {
-	button := g.getClips("button")[0]
-	button.OnPress(func(left, right, middle, alt, control bool) {
-		g.button = buttonPressed
+	g.hovered = nil
+	g.getClips("fg", "button")[0].SetCursor(desktop.PointerCursor)
+	g.movie.OnKey(g.onKey)
+	g.onAction("game.restart", func(clip *clips.Clip, i int, ev *clips.Event) {
+		g.restart()
+	})
+	g.onAction("view.zoomIn", func(clip *clips.Clip, i int, ev *clips.Event) {
+		g.zoom(1)
+	})
+	g.onAction("view.zoomOut", func(clip *clips.Clip, i int, ev *clips.Event) {
+		g.zoom(-1)
+	})
+	g.setTileHandlers(g.getClips("board", "icons"))
+	if g.c.touch {
+		g.setTouchHandlers()
+		return
+	}
+	g.onAction("button.press", func(clip *clips.Clip, i int, ev *clips.Event) {
+		g.button = boards.ButtonPressed
 		g.updateButton()
 	})
-	button.OnRelease(func(left, right, middle, alt, control bool) {
-		if g.button == buttonPressed {
+	g.onAction("button.release", func(clip *clips.Clip, i int, ev *clips.Event) {
+		if g.button == boards.ButtonPressed {
 			g.restart()
 		}
 	})
-	button.OnLeave(func() {
-		if g.button == buttonPressed {
+	g.onAction("button.leave", func(clip *clips.Clip, i int, ev *clips.Event) {
+		if g.button == boards.ButtonPressed {
 			g.restart()
 		}
 	})
-	icons := g.getClips("icons")
-	for y := 0; y < g.c.height; y++ {
-		for x := 0; x < g.c.width; x++ {
-			px, py := x, y
-			icons[y*g.c.width+x].OnPress(func(left, right, middle, alt, control bool) {
-				if g.state == stateWon || g.state == stateLost {
-					return
-				}
-				if !right {
-					if !g.tiles[py][px].marked {
-						g.button = buttonEvaluate
-						g.updateButton()
-						g.tiles[py][px].pressed = true
-						g.updateTile(px, py)
-						if g.tiles[py][px].open {
-							g.forEachNeighbour(px, py, func(x, y int) {
-								if !g.tiles[y][x].marked {
-									g.tiles[y][x].pressed = true
-									g.updateTile(x, y)
-								}
-							})
-						}
-					}
-				}
-				if right {
-					if !g.tiles[py][px].open {
-						if g.tiles[py][px].marked {
-							g.tiles[py][px].marked = false
-							g.bombs++
-						} else {
-							g.tiles[py][px].marked = true
-							g.bombs--
-						}
-						g.updateBombDigits()
-						g.updateTile(px, py)
-					}
-				}
-			})
-			icons[y*g.c.width+x].OnRelease(func(left, right, middle, alt, control bool) {
-				if g.state == stateWon || g.state == stateLost {
-					return
-				}
-				g.button = buttonPlaying
-				g.updateButton()
-				if !right {
-					if !g.tiles[py][px].open {
-						if g.tiles[py][px].pressed {
-							g.tiles[py][px].pressed = false
-							g.onPressTile(px, py)
-							g.updateAllTiles()
-						}
-					} else {
-						var marks = 0
-						g.forEachNeighbour(px, py, func(x, y int) {
-							if g.tiles[y][x].marked {
-								marks++
-							}
-						})
-						if g.tiles[py][px].number == marks {
-							g.forEachNeighbour(px, py, func(x, y int) {
-								if !g.tiles[y][x].open && !g.tiles[y][x].marked {
-									g.onPressTile(x, y)
-								}
-							})
-							g.updateAllTiles()
-						} else {
-							g.forEachNeighbour(px, py, func(x, y int) {
-								if !g.tiles[y][x].marked {
-									g.tiles[y][x].pressed = false
-									g.updateTile(x, y)
-								}
-							})
-						}
-					}
+	g.onAction("tile.press", func(clip *clips.Clip, i int, ev *clips.Event) {
+		g.onTilePress(i%g.c.width, i/g.c.width, ev)
+	})
+	g.onAction("tile.release", func(clip *clips.Clip, i int, ev *clips.Event) {
+		g.onTileRelease(i%g.c.width, i/g.c.width, ev)
+	})
+	g.onAction("tile.enter", func(clip *clips.Clip, i int, ev *clips.Event) {
+		g.onTileEnter(i%g.c.width, i/g.c.width, ev)
+	})
+	g.onAction("tile.leave", func(clip *clips.Clip, i int, ev *clips.Event) {
+		g.onTileLeave(i%g.c.width, i/g.c.width)
+	})
+}
+
+// setTileHandlers sets the handlers of the tiles that are not bound in JSON,
+// the handlers find the tile by the index of the clip when they are called,
+// so they stay right when the board gets another size
+func (g *game) setTileHandlers(icons []*clips.Clip) {
+	for _, icon := range icons {
+		icon := icon
+		icon.OnKey(func(ev *clips.Event) bool {
+			return g.onTileKey(icon.GetIndex(), ev)
+		})
+		if g.c.touch {
+			g.setTouchTileHandlers(icon)
+			continue
+		}
+		icon.OnDrag(func(ev *clips.Event) {
+			g.locked(func() {
+				if g.panning {
+					g.scrollBy(-ev.Delta.DX, -ev.Delta.DY)
 				}
 			})
-			icons[y*g.c.width+x].OnEnter(func(left, right, middle, alt, control bool) {
-				if g.state == stateWon || g.state == stateLost {
-					return
-				}
-				if left {
-					g.button = buttonEvaluate
-					g.updateButton()
-					g.tiles[py][px].pressed = true
-					g.updateTile(px, py)
-					if g.tiles[py][px].open {
-						g.forEachNeighbour(px, py, func(x, y int) {
-							if !g.tiles[y][x].marked {
-								g.tiles[y][x].pressed = true
-								g.updateTile(x, y)
-							}
-						})
-					}
-				}
+		})
+		icon.OnScroll(func(ev *clips.Event) {
+			g.locked(func() {
+				g.onScroll(ev)
 			})
-			icons[y*g.c.width+x].OnLeave(func() {
-				if g.state == stateWon || g.state == stateLost {
-					return
-				}
-				g.button = buttonPlaying
-				g.updateButton()
-				g.tiles[py][px].pressed = false
-				if g.tiles[py][px].open {
-					g.forEachNeighbour(px, py, func(x, y int) {
-						if !g.tiles[y][x].marked {
-							g.tiles[y][x].pressed = false
-							g.updateTile(x, y)
-						}
-					})
-				}
-				g.updateTile(px, py)
+		})
+		icon.OnOver(func(left, right, middle, alt, control bool) {
+			g.locked(func() {
+				i := icon.GetIndex()
+				g.hover(i%g.c.width, i/g.c.width)
 			})
-		}
+		})
 	}
 }
 
-func (g *game) forEachNeighbour(x, y int, do func(x, y int)) {
-	for i := 0; i < 9; i++ {
-		dy, dx := i/3-1, i%3-1
-		if dy == 0 && dx == 0 {
-			continue
-		}
-		if y+dy < 0 || x+dx < 0 {
-			continue
+// onTilePress presses a tile (and its neighbours when it is open) with the
+// left button, marks it with the right button and starts panning with the middle
+func (g *game) onTilePress(x, y int, ev *clips.Event) {
+	if ev.Middle {
+		g.startPan()
+		return
+	}
+	if g.board.IsOver() {
+		return
+	}
+	if ev.Right {
+		g.toggleMark(x, y)
+	} else if !g.board.GetTile(x, y).Marked {
+		g.pressTiles(x, y, true)
+	}
+}
+
+// onTileRelease digs the pressed tile or chords on an open tile
+func (g *game) onTileRelease(px, py int, ev *clips.Event) {
+	if g.panning {
+		g.panning = false
+		return
+	}
+	if g.board.IsOver() {
+		return
+	}
+	g.button = boards.ButtonPlaying
+	g.updateButton()
+	if ev.Right {
+		return
+	}
+	if !g.board.GetTile(px, py).Open {
+		if g.board.GetTile(px, py).Pressed {
+			g.board.SetPressed(px, py, false)
+			g.dig(px, py)
 		}
-		if y+dy >= g.c.height || x+dx >= g.c.width {
-			continue
+	} else if !g.chord(px, py) {
+		g.board.ForEachNeighbour(px, py, func(x, y int) {
+			if !g.board.GetTile(x, y).Marked {
+				g.board.SetPressed(x, y, false)
+				g.updateTile(x, y)
+			}
+		})
+	}
+}
+
+// onTileEnter presses the tile when the left button is held while entering it
+func (g *game) onTileEnter(x, y int, ev *clips.Event) {
+	g.hover(x, y)
+	if g.panning {
+		if ev.Middle {
+			return
 		}
-		do(x+dx, y+dy)
+		g.panning = false
+	}
+	if g.board.IsOver() {
+		return
+	}
+	if ev.Left {
+		g.pressTiles(x, y, true)
 	}
 }
 
-func (g *game) onPressTile(x, y int) {
-	if g.state == stateWaiting {
-		g.state = statePlaying
+// onTileLeave releases the tile (and its neighbours) without digging
+func (g *game) onTileLeave(x, y int) {
+	g.hover(-1, -1)
+	if g.board.IsOver() {
+		return
+	}
+	g.button = boards.ButtonPlaying
+	g.updateButton()
+	g.pressTiles(x, y, false)
+}
+
+// pressTiles shows a tile, and the unmarked neighbours of an open tile, as
+// pressed or not
+func (g *game) pressTiles(px, py int, pressed bool) {
+	if pressed {
+		g.button = boards.ButtonEvaluate
+		g.updateButton()
+	}
+	g.board.SetPressed(px, py, pressed)
+	g.updateTile(px, py)
+	if g.board.GetTile(px, py).Open {
+		g.board.ForEachNeighbour(px, py, func(x, y int) {
+			if !g.board.GetTile(x, y).Marked {
+				g.board.SetPressed(x, y, pressed)
+				g.updateTile(x, y)
+			}
+		})
+	}
+}
+
+// dig opens a closed tile or opens the neighbours of an open tile
+func (g *game) dig(x, y int) {
+	waiting := g.board.GetState() == boards.StateWaiting
+	if g.board.Dig(x, y) {
+		g.updateBoard(waiting)
+	}
+}
+
+// chord opens the unmarked neighbours of an open tile when the number of
+// marked neighbours matches its number and returns whether it did
+func (g *game) chord(x, y int) bool {
+	if !g.board.Chord(x, y) {
+		return false
+	}
+	g.updateBoard(false)
+	return true
+}
+
+// updateBoard shows the board after tiles were opened, the timer starts
+// with the first opened tile
+func (g *game) updateBoard(waiting bool) {
+	if waiting {
 		g.time = time.Now().UnixNano()
 		g.updateTimeDigits()
-		g.placeBombs(x, y, g.bombs)
-	}
-	if !g.tiles[y][x].open && !g.tiles[y][x].marked {
-		g.tiles[y][x].open = true
-		g.closed--
-		if g.tiles[y][x].bomb {
-			g.state = stateLost
-			g.button = buttonLost
-			g.updateButton()
-			return
-		}
-		if g.closed == g.c.bombs {
-			g.state = stateWon
-			g.button = buttonWon
-			g.updateButton()
-			g.bombs = 0
-			g.updateBombDigits()
-			return
-		}
-		if g.tiles[y][x].number == 0 {
-			g.forEachNeighbour(x, y, func(px, py int) {
-				g.onPressTile(px, py)
-			})
-		}
 	}
+	if g.board.IsOver() {
+		g.button = g.board.GetButton()
+		g.updateButton()
+		g.updateBombDigits()
+	}
+	g.updateAllTiles()
+}
+
+// toggleMark marks or unmarks a closed tile as a bomb
+func (g *game) toggleMark(x, y int) {
+	if g.board.GetTile(x, y).Open {
+		return
+	}
+	g.board.ToggleMark(x, y)
+	g.updateBombDigits()
+	g.updateTile(x, y)
+	g.updateHover()
+	g.record()
 }
 
 func (g *game) updateButton() {
-	button := g.getClips("button")[0]
-	button.GotoFrame(g.button, true)
+	g.set("button", g.button)
 }
 
-func (g *game) updateBombDigits() {
-	bombsDigits := g.getClips("bombs")
-	bombs := g.bombs
-	if bombs < -99 {
-		bombs = -99
-	}
-	negative := false
-	if bombs < 0 {
-		negative = true
-		bombs *= -1
-	}
-	for i := 0; i < 3; i++ {
-		if i == 2 && negative {
-			bombsDigits[2-i].GotoFrame(10, true)
-		} else {
-			bombsDigits[2-i].GotoFrame(bombs%10, true)
-		}
-		bombs /= 10
+// set sets a value that the frames of the clips in the movie are bound to
+func (g *game) set(name string, value interface{}) {
+	err := g.movie.Set(name, value)
+	if err != nil {
+		log.Println(err)
 	}
 }
 
+func (g *game) updateBombDigits() {
+	g.set("bombs", g.board.GetRemaining())
+}
+
 func (g *game) updateTimeDigits() {
-	if g.state == statePlaying {
-		time := int((time.Now().UnixNano() - g.time) / 1000000000)
-		if time > 999 {
-			time = 999
-		}
-		timeDigits := g.getClips("time")
-		for i := 0; i < 3; i++ {
-			timeDigits[2-i].GotoFrame(time%10, true)
-			time /= 10
-		}
+	switch g.board.GetState() {
+	case boards.StateWaiting:
+		g.seconds = 0
+	case boards.StatePlaying:
+		g.seconds = int((time.Now().UnixNano() - g.time) / 1000000000)
 	}
+	g.set("seconds", g.seconds)
 }
 
 func (g *game) updateAllTiles() {
-	icons := g.getClips("icons")
-	if g.state == stateWon || g.state == stateLost {
-		for y := 0; y < g.c.height; y++ {
-			for x := 0; x < g.c.width; x++ {
-				icon := iconClosed
-				if g.tiles[y][x].open {
-					if g.tiles[y][x].bomb {
-						icon = iconAnswerIsBomb
-					} else {
-						icon = g.tiles[y][x].number
-					}
-				} else {
-					if g.tiles[y][x].marked {
-						if g.tiles[y][x].bomb {
-							icon = iconMarked
-						} else {
-							icon = iconAnswerNoBomb
-						}
-					} else {
-						if g.tiles[y][x].bomb {
-							if g.state == stateWon {
-								icon = iconMarked
-							} else {
-								icon = iconBomb
-							}
-						}
-					}
-				}
-				icons[y*g.c.width+x].GotoFrame(icon, false)
-			}
-		}
-	} else {
-		for y := 0; y < g.c.height; y++ {
-			for x := 0; x < g.c.width; x++ {
-				icon := iconClosed
-				if g.tiles[y][x].open {
-					icon = g.tiles[y][x].number
-				} else {
-					if g.tiles[y][x].marked {
-						icon = iconMarked
-					} else {
-						if g.tiles[y][x].pressed {
-							icon = iconEmpty
-						}
-						if g.state == stateWon {
-							icon = iconMarked
-						}
-					}
-				}
-				icons[y*g.c.width+x].GotoFrame(icon, false)
-			}
-		}
+	err := g.board.Show(g.getClips("board", "icons"))
+	if err != nil {
+		log.Println(err)
 	}
+	g.updateHover()
 	g.movie.GetContainer().Refresh()
+	g.record()
 }
 
 func (g *game) updateTile(x, y int) {
-	icons := g.getClips("icons")
-	icon := iconClosed
-	if g.tiles[y][x].open {
-		icon = g.tiles[y][x].number
-	} else {
-		if g.tiles[y][x].marked {
-			icon = iconMarked
-		} else {
-			if g.tiles[y][x].pressed {
-				icon = iconEmpty
-			}
-			if g.state == stateWon {
-				icon = iconMarked
-			}
-		}
+	err := g.board.ShowTile(g.getClips("board", "icons"), x, y)
+	if err != nil {
+		log.Println(err)
 	}
-	icons[y*g.c.width+x].GotoFrame(icon, true)
 }
 
 func (g *game) restart() {
-	g.state = stateWaiting
-	g.button = buttonPlaying
+	g.recording = recording{}
+	g.board = boards.New(g.c.width, g.c.height, g.c.bombs, rand.New(rand.NewSource(time.Now().UnixNano())))
+	g.button = g.board.GetButton()
 	g.updateButton()
-	g.bombs = g.c.bombs
 	g.updateBombDigits()
-	g.closed = g.c.width * g.c.height
 	g.time = time.Now().UnixNano()
 	g.updateTimeDigits()
-	g.tiles = make([][]tile, g.c.height)
-	for y := 0; y < g.c.height; y++ {
-		g.tiles[y] = make([]tile, g.c.width)
-		for x := 0; x < g.c.width; x++ {
-			g.tiles[y][x] = tile{}
-		}
-	}
 	g.updateAllTiles()
 }
 
-func (g *game) placeBombs(x, y, bombs int) {
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	b := bombs
-	g.tiles[y][x].bomb = true
-	for b > 0 {
-		x, y := rng.Intn(g.c.width), rng.Intn(g.c.height)
-		if !g.tiles[y][x].bomb {
-			g.tiles[y][x].bomb = true
-			b--
-			g.forEachNeighbour(x, y, func(x, y int) {
-				g.tiles[y][x].number++
-			})
-		}
+// rebuild recreates the movie while keeping the game state, the lock of the
+// game is held
+func (g *game) rebuild() {
+	g.devError = g.init()
+	if g.devError == nil {
+		g.setHandlers()
+		g.show()
+		g.updateButton()
+		g.updateBombDigits()
+		g.updateTimeDigits()
+		g.updateAllTiles()
+		return
+	}
+	g.show()
+}
+
+func (g *game) show() {
+	content := g.newContent()
+	if g.c.touch {
+		content = container.NewBorder(nil, g.newModeToggle(), nil, nil, content)
+	}
+	if g.devError != nil {
+		content = container.NewStack(content, newErrorOverlay(g.devError))
 	}
-	g.tiles[y][x].bomb = false
+	g.window.SetContent(content)
+	g.window.Resize(fyne.NewSize(0, 0))
 }
 
 func NewGame(config config, window fyne.Window) *game {
-	g := &game{c: config}
-	g.init()
+	g := &game{c: config, window: window, hoverX: -1, hoverY: -1}
+	err := g.init()
+	if err != nil {
+		if devDir == "" {
+			log.Fatalln(err)
+		}
+		g.devError = err
+		g.movie, err = g.loadMovie(spriteMapMeta, movieScenes)
+		if err != nil {
+			log.Fatalln(err)
+		}
+	}
 	g.setHandlers()
+	g.show()
 	g.restart()
-	window.SetContent(g.movie.GetContainer())
-	window.Resize(fyne.NewSize(0, 0))
 	return g
 }
 
 func main() {
+	flag.StringVar(&devDir, "dev", os.Getenv("FYNE_MINES_DEV"), "load and watch sprites.json and movie.json in this directory")
+	flag.Parse()
 	a := app.NewWithID("com.tqdev.fyne-mines")
 	a.SetIcon(resourceMinesiconPng)
 	w := a.NewWindow("Fyne Mines")
@@ -493,39 +500,97 @@ func main() {
 	c := config{
 		scale:   2,
 		holding: 15,
+		touch:   fyne.CurrentDevice().IsMobile(),
 	}
-	menuItemBeginner := fyne.NewMenuItem("Beginner", func() {
-		c.width = 9
-		c.height = 9
-		c.bombs = 10
+	c.hover = !c.touch
+	newGame := func(width, height, bombs int) {
+		if g != nil {
+			var err error
+			g.locked(func() {
+				err = g.setDifficulty(width, height, bombs)
+			})
+			if err == nil {
+				return
+			}
+			log.Println(err)
+			g.locked(func() {
+				c.scale = g.c.scale
+			})
+		}
+		c.width = width
+		c.height = height
+		c.bombs = bombs
 		g = NewGame(c, w)
+	}
+	menuItemBeginner := fyne.NewMenuItem("Beginner", func() {
+		newGame(9, 9, 10)
 	})
 	menuItemIntermediate := fyne.NewMenuItem("Intermediate", func() {
-		c.width = 16
-		c.height = 16
-		c.bombs = 40
-		g = NewGame(c, w)
+		newGame(16, 16, 40)
 	})
 	menuItemExpert := fyne.NewMenuItem("Expert", func() {
-		c.width = 30
-		c.height = 16
-		c.bombs = 99
-		g = NewGame(c, w)
+		newGame(30, 16, 99)
+	})
+	menuItemScreenshot := fyne.NewMenuItem("Save Screenshot...", func() {
+		var scale int
+		g.locked(func() {
+			scale = g.c.scale
+		})
+		showExportDialog("Save Screenshot", ".png", scale, g.writeScreenshot, w)
+	})
+	menuItemAnimation := fyne.NewMenuItem("Export Animated GIF...", func() {
+		var scale int
+		g.locked(func() {
+			scale = g.c.scale
+		})
+		showExportDialog("Export Animated GIF", ".gif", scale, g.writeAnimation, w)
 	})
-	menuGame := fyne.NewMenu("Game ", menuItemBeginner, menuItemIntermediate, menuItemExpert)
+	menuGame := fyne.NewMenu("Game ", menuItemBeginner, menuItemIntermediate, menuItemExpert,
+		fyne.NewMenuItemSeparator(), menuItemScreenshot, menuItemAnimation)
 	menuItemAbout := fyne.NewMenuItem("About...", func() {
 		dialog.ShowInformation("About Fyne Mines v1.1.3", "Author: Maurits van der Schee\n\ngithub.com/mevdschee/fyne-mines", w)
 	})
+	menuItemZoomIn := fyne.NewMenuItem("Zoom In", func() {
+		g.locked(func() {
+			g.zoom(1)
+		})
+	})
+	menuItemZoomOut := fyne.NewMenuItem("Zoom Out", func() {
+		g.locked(func() {
+			g.zoom(-1)
+		})
+	})
+	menuItemHover := fyne.NewMenuItem("Highlight Tiles", nil)
+	menuItemHover.Checked = c.hover
+	menuView := fyne.NewMenu("View ", menuItemZoomIn, menuItemZoomOut, fyne.NewMenuItemSeparator(), menuItemHover)
 	menuHelp := fyne.NewMenu("Help ", menuItemAbout)
-	mainMenu := fyne.NewMainMenu(menuGame, menuHelp)
+	mainMenu := fyne.NewMainMenu(menuGame, menuView, menuHelp)
+	menuItemHover.Action = func() {
+		c.hover = !c.hover
+		menuItemHover.Checked = c.hover
+		mainMenu.Refresh()
+		g.locked(func() {
+			g.setHoverEnabled(c.hover)
+		})
+	}
 	w.SetMainMenu(mainMenu)
 	w.SetPadded(false)
 	menuItemBeginner.Action()
-	w.SetContent(g.movie.GetContainer())
 	w.SetFixedSize(true)
+	w.Canvas().SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		g.handleKey(clips.NewKeyEvent(ev))
+	})
+	w.Canvas().SetOnTypedRune(func(r rune) {
+		g.handleKey(clips.NewRuneEvent(r))
+	})
+	if devDir != "" {
+		watchSources(func() {
+			g.locked(g.rebuild)
+		})
+	}
 	go func() {
 		for range time.Tick(time.Millisecond * 100) {
-			g.updateTimeDigits()
+			g.locked(g.updateTimeDigits)
 		}
 	}()
 	w.ShowAndRun()
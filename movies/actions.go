@@ -0,0 +1,57 @@
+package movies
+
+import (
+	"fmt"
+
+	"github.com/mevdschee/fyne-mines/clips"
+	"github.com/mevdschee/fyne-mines/scenes"
+)
+
+// Action handles an event that is bound to it by name in JSON, it gets the
+// clip and its repeat index
+type Action func(clip *clips.Clip, i int, ev *clips.Event)
+
+// OnAction sets the handler of an action that clips bind to in JSON, events
+// that are bound to an action without a handler are ignored
+func (m *Movie) OnAction(name string, action Action) {
+	m.actions[name] = action
+}
+
+// bindActions binds the events of the clips of a scene to the actions that
+// are named in JSON
+func (m *Movie) bindActions(scene *scenes.Scene) error {
+	for _, layer := range scene.GetOrderedLayers() {
+		err := m.bindClipActions(layer.GetClips())
+		if err != nil {
+			return fmt.Errorf("scene '%s': layer '%s': %v", scene.GetName(), layer.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// bindClipActions binds the events of clips to the actions that are named
+// in JSON, it returns an error for an event that a clip does not have
+func (m *Movie) bindClipActions(list []*clips.Clip) error {
+	for _, clip := range list {
+		for event, name := range clip.GetActions() {
+			clip, name := clip, name
+			err := clip.Bind(event, func(ev *clips.Event) {
+				m.dispatch(name, clip, ev)
+			})
+			if err != nil {
+				return fmt.Errorf("clip '%s': on: %v", clip.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// dispatch calls the handler of an action
+func (m *Movie) dispatch(name string, clip *clips.Clip, ev *clips.Event) {
+	action, ok := m.actions[name]
+	if !ok {
+		return
+	}
+	ev.Clip = clip
+	action(clip, clip.GetIndex(), ev)
+}
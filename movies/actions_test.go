@@ -0,0 +1,118 @@
+package movies
+
+import (
+	"os"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+	"github.com/mevdschee/fyne-mines/clips"
+	"github.com/mevdschee/fyne-mines/layers"
+	"github.com/mevdschee/fyne-mines/sprites"
+)
+
+func TestUnknownEvent(t *testing.T) {
+	test.NewApp()
+	spriteMap, _ := readSkin(t)
+	data := `{"scenes":[{"name":"game","layers":[{"name":"board","clips":[
+		{"sprite":"icons","name":"icons","x":"0","y":"0","on":{"press":"tile.press"}},
+		{"sprite":"icons","name":"extra","if":"w > 1","x":"16","y":"0","on":{"hover":"tile.hover"}}]}]}]}`
+	_, err := FromJSON(spriteMap, data, map[string]interface{}{"w": 2, "s": 1}, layers.NewFunctions())
+	want := "scene 'game': layer 'board': clip 'extra': on: unknown event 'hover'"
+	if err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+	m, err := FromJSON(spriteMap, data, map[string]interface{}{"w": 1, "s": 1}, layers.NewFunctions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = m.SetParameters(map[string]interface{}{"w": 2, "s": 1})
+	want = "scene 'game': clip 'extra': on: unknown event 'hover'"
+	if err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %s", err, want)
+	}
+}
+
+// TestRepeatGrowsAndShrinks lays out a movie for more and then for fewer
+// instances of a clip, the removed clips are gone and the handlers of the
+// clips that are kept still work
+func TestRepeatGrowsAndShrinks(t *testing.T) {
+	test.NewApp()
+	spriteMap, _ := readSkin(t)
+	data := `{"scenes":[{"name":"game","layers":[{"name":"board","clips":[
+		{"sprite":"icons","name":"icons","repeat":"n","x":"i*16","y":"0","on":{"press":"tile.press"}}]}]}]}`
+	m, err := FromJSON(spriteMap, data, map[string]interface{}{"n": 2, "s": 1}, layers.NewFunctions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pressed := []int{}
+	m.OnAction("tile.press", func(clip *clips.Clip, i int, ev *clips.Event) {
+		pressed = append(pressed, i)
+	})
+	icons, _ := m.GetClips("game", "board", "icons")
+	first := icons[0]
+	keys := 0
+	first.OnKey(func(ev *clips.Event) bool {
+		keys++
+		return true
+	})
+	added, err := m.SetParameters(map[string]interface{}{"n": 4, "s": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	icons, _ = m.GetClips("game", "board", "icons")
+	if len(added) != 2 || added[0] != icons[2] || added[1] != icons[3] {
+		t.Fatalf("added %d clips, want the last 2 of %d", len(added), len(icons))
+	}
+	removed := icons[1:]
+	added, err = m.SetParameters(map[string]interface{}{"n": 1, "s": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	icons, _ = m.GetClips("game", "board", "icons")
+	if len(added) != 0 || len(icons) != 1 || icons[0] != first {
+		t.Fatalf("%d clips after shrinking, %d added", len(icons), len(added))
+	}
+	layer := m.scenes["game"].GetLayers()["board"]
+	for _, clip := range removed {
+		for _, o := range layer.GetContainer().Objects {
+			if o == fyne.CanvasObject(clip.GetContainer()) {
+				t.Errorf("removed clip %d is still in the layer", clip.GetIndex())
+			}
+		}
+		for _, c := range layer.GetClips() {
+			if c == clip {
+				t.Errorf("removed clip %d is still in the clips of the layer", clip.GetIndex())
+			}
+		}
+	}
+	if list := layer.GetClipsByName("icons"); len(list) != 1 || list[0] != first {
+		t.Errorf("layer has %d clips by the name 'icons', want 1", len(list))
+	}
+	first.MouseDown(&desktop.MouseEvent{Button: desktop.MouseButtonPrimary})
+	first.HandleKey(clips.NewKeyEvent(&fyne.KeyEvent{Name: fyne.KeyReturn}))
+	if len(pressed) != 1 || pressed[0] != 0 || keys != 1 {
+		t.Errorf("kept clip pressed %v and handled %d keys, want [0] and 1", pressed, keys)
+	}
+}
+
+func readSkin(t *testing.T) (sprites.SpriteMap, string) {
+	imageData, err := os.ReadFile("../winxpskin.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta, err := os.ReadFile("../skin/sprites.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile("../skin/movie.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spriteMap, err := sprites.NewSpriteMap(imageData, string(meta))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return spriteMap, string(data)
+}
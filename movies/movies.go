@@ -4,49 +4,97 @@ import (
 	"encoding/json"
 	"fmt"
 	"image"
+	"strings"
+	"sync"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"github.com/mevdschee/fyne-mines/clips"
+	"github.com/mevdschee/fyne-mines/layers"
 	"github.com/mevdschee/fyne-mines/scenes"
 	"github.com/mevdschee/fyne-mines/sprites"
 )
 
-// Movie is a set of scenes
+// Movie is a set of scenes, the mutex guards the current scene and the
+// transition as these are also changed by the shared ticker
 type Movie struct {
+	mutex        sync.Mutex
 	container    *fyne.Container
 	currentScene *scenes.Scene
 	scenes       map[string]*scenes.Scene
+	transition   *clips.Tween
+	curtain      *canvas.Rectangle
+	finish       func()
+	hooks        []func()
+	onKey        func(ev *clips.Event) bool
+	actions      map[string]Action
+	vars         map[string]string
+	functions    *layers.Functions
+}
+
+// MovieJSON is a movie in JSON, it may also be just the list of scenes
+type MovieJSON struct {
+	Vars   map[string]string
+	Scenes []scenes.SceneJSON
+}
+
+// parse reads a movie from JSON, either an object with vars and scenes or
+// just the list of scenes
+func parse(data string, strict bool) (MovieJSON, error) {
+	movieJSON := MovieJSON{}
+	decoder := json.NewDecoder(strings.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	var err error
+	if strings.HasPrefix(strings.TrimSpace(data), "[") {
+		err = decoder.Decode(&movieJSON.Scenes)
+	} else {
+		err = decoder.Decode(&movieJSON)
+	}
+	return movieJSON, err
 }
 
 // New creates a new movie
 func New() *Movie {
 	return &Movie{
-		container:    container.NewStack(),
+		container:    container.NewWithoutLayout(),
 		currentScene: nil,
 		scenes:       map[string]*scenes.Scene{},
+		actions:      map[string]Action{},
+		functions:    layers.NewFunctions(),
 	}
 }
 
-// FromJSON creates a new movie from JSON
-func FromJSON(spriteMap sprites.SpriteMap, data string, parameters map[string]interface{}) (*Movie, error) {
-	sceneJSONs := []scenes.SceneJSON{}
-	err := json.Unmarshal([]byte(data), &sceneJSONs)
+// FromJSON creates a new movie from JSON, its vars and expressions may call
+// the functions, these are kept with the movie
+func FromJSON(spriteMap sprites.SpriteMap, data string, parameters map[string]interface{}, functions *layers.Functions) (*Movie, error) {
+	movieJSON, err := parse(data, false)
+	if err != nil {
+		return nil, err
+	}
+	err = functions.EvalVars(movieJSON.Vars, parameters)
 	if err != nil {
 		return nil, err
 	}
 	movie := Movie{
-		container:    container.NewStack(),
-		currentScene: &scenes.Scene{},
+		container:    container.NewWithoutLayout(),
+		currentScene: nil,
 		scenes:       map[string]*scenes.Scene{},
+		actions:      map[string]Action{},
+		vars:         movieJSON.Vars,
+		functions:    functions,
 	}
-	for _, sceneJSON := range sceneJSONs {
-		scene, err := scenes.FromJSON(spriteMap, sceneJSON, parameters)
+	for _, sceneJSON := range movieJSON.Scenes {
+		scene, err := scenes.FromJSON(spriteMap, sceneJSON, parameters, functions)
+		if err != nil {
+			return nil, err
+		}
+		err = movie.Add(scene)
 		if err != nil {
 			return nil, err
 		}
-		movie.Add(scene)
 	}
 	return &movie, nil
 }
@@ -64,13 +112,97 @@ func (m *Movie) SetSize(width, height int) {
 	c.Add(i)
 }
 
-// Add adds a scene to the movie
-func (m *Movie) Add(scene *scenes.Scene) {
+// Add adds a scene to the movie, only the first scene is shown, the events
+// of its clips are bound to the actions that are named in JSON, it returns an
+// error when a clip is bound to an unknown event
+func (m *Movie) Add(scene *scenes.Scene) error {
+	err := m.bindActions(scene)
+	if err != nil {
+		return err
+	}
 	m.scenes[scene.GetName()] = scene
+	scene.SetParent(m)
 	if len(m.scenes) == 1 {
 		m.currentScene = scene
+	} else {
+		scene.GetContainer().Hide()
 	}
 	m.container.Add(scene.GetContainer())
+	return nil
+}
+
+// OnKey sets the handler for the key events that the scenes do not handle,
+// it returns whether it handled the event
+func (m *Movie) OnKey(handler func(ev *clips.Event) bool) {
+	m.onKey = handler
+}
+
+// HandleKey handles a key event that bubbles up from a scene, key events
+// that no clip received (such as those of the canvas) can be passed here
+func (m *Movie) HandleKey(ev *clips.Event) bool {
+	return m.onKey != nil && m.onKey(ev)
+}
+
+// Set sets a value that the frame expressions of the clips may use, the
+// frames of the clips that use it are updated
+func (m *Movie) Set(name string, value interface{}) error {
+	var first error
+	for _, scene := range m.scenes {
+		err := scene.Set(name, value)
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// SetParameters evaluates the vars and the expressions of the clips again
+// with other parameters, clips are moved, resized, added or removed instead of
+// creating the movie again, the events of added clips are bound to actions
+// and the added clips are returned
+func (m *Movie) SetParameters(parameters map[string]interface{}) ([]*clips.Clip, error) {
+	err := m.functions.EvalVars(m.vars, parameters)
+	if err != nil {
+		return nil, err
+	}
+	added := []*clips.Clip{}
+	for _, scene := range m.scenes {
+		list, err := scene.SetParameters(parameters)
+		if err != nil {
+			return nil, err
+		}
+		err = m.bindClipActions(list)
+		if err != nil {
+			return nil, fmt.Errorf("scene '%s': %v", scene.GetName(), err)
+		}
+		added = append(added, list...)
+	}
+	return added, nil
+}
+
+// GetCurrentScene gets the scene that is shown
+func (m *Movie) GetCurrentScene() *scenes.Scene {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.currentScene
+}
+
+// GotoScene shows the scene with the given name and hides the others
+func (m *Movie) GotoScene(name string) error {
+	return m.GotoSceneWithTransition(name, TransitionNone, 0)
+}
+
+// Render draws the visible clips of a scene layer by layer on a new image
+// (in unscaled pixels) without a GPU
+func (m *Movie) Render(scene string) (image.Image, error) {
+	s, ok := m.scenes[scene]
+	if !ok {
+		return nil, fmt.Errorf("Render: scene '%s' not found", scene)
+	}
+	bounds := s.GetBounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Max.X, bounds.Max.Y))
+	s.Draw(dst)
+	return dst, nil
 }
 
 // GetClip gets a clip from the movie
@@ -86,18 +218,53 @@ func (m *Movie) getClip(scene, layer, clip string, i int) (*clips.Clip, error) {
 	return nil, fmt.Errorf("getClip: scene '%s' not found", scene)
 }
 
-// GetClips gets a series of clips from the movie
+// GetClips gets a series of clips from the movie, the slice is shared with
+// the layer and must not be changed
 func (m *Movie) GetClips(scene, layer, clip string) ([]*clips.Clip, error) {
-	clips := []*clips.Clip{}
-	for i := 0; true; i++ {
-		c, err := m.getClip(scene, layer, clip, i)
+	s, ok := m.scenes[scene]
+	if !ok {
+		return nil, fmt.Errorf("GetClips: scene '%s' not found", scene)
+	}
+	list, err := s.GetClips(layer, clip)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("GetClips: clip '%s' not found", clip)
+	}
+	return list, nil
+}
+
+// Validate checks a movie in JSON with the functions that it may call and
+// reports every problem with its JSON path, clips are checked against the
+// bounds unless these are empty
+func Validate(spriteMap sprites.SpriteMap, data string, parameters map[string]interface{}, functions *layers.Functions, bounds image.Rectangle) []error {
+	movieJSON, err := parse(data, true)
+	if err != nil {
+		return []error{fmt.Errorf("$: %v", err)}
+	}
+	errs := []error{}
+	prefix := "$"
+	if !strings.HasPrefix(strings.TrimSpace(data), "[") {
+		prefix = "$.scenes"
+		env := map[string]interface{}{}
+		for k, v := range parameters {
+			env[k] = v
+		}
+		err = functions.EvalVars(movieJSON.Vars, env)
 		if err != nil {
-			if i == 0 {
-				return clips, err
-			}
-			break
+			return append(errs, fmt.Errorf("$.vars: %v", err))
+		}
+		parameters = env
+	}
+	names := map[string]bool{}
+	for i, sceneJSON := range movieJSON.Scenes {
+		path := fmt.Sprintf("%s[%d]", prefix, i)
+		if names[sceneJSON.Name] {
+			errs = append(errs, fmt.Errorf("%s.name: duplicate scene name '%s'", path, sceneJSON.Name))
 		}
-		clips = append(clips, c)
+		names[sceneJSON.Name] = true
+		errs = append(errs, scenes.Validate(spriteMap, sceneJSON, parameters, functions, bounds, path)...)
 	}
-	return clips, nil
+	return errs
 }
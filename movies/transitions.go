@@ -0,0 +1,198 @@
+package movies
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"github.com/mevdschee/fyne-mines/clips"
+	"github.com/mevdschee/fyne-mines/scenes"
+)
+
+// Transition is the way one scene is replaced by another
+type Transition int
+
+const (
+	// TransitionNone switches scenes immediately
+	TransitionNone Transition = iota
+	// TransitionFade fades to black and then into the new scene
+	TransitionFade
+	// TransitionSlideLeft moves the new scene in from the right
+	TransitionSlideLeft
+	// TransitionSlideRight moves the new scene in from the left
+	TransitionSlideRight
+	// TransitionSlideUp moves the new scene in from the bottom
+	TransitionSlideUp
+	// TransitionSlideDown moves the new scene in from the top
+	TransitionSlideDown
+)
+
+// GotoSceneWithTransition shows the scene with the given name using a transition
+func (m *Movie) GotoSceneWithTransition(name string, transition Transition, duration time.Duration) error {
+	next, ok := m.scenes[name]
+	if !ok {
+		return fmt.Errorf("GotoScene: scene '%s' not found", name)
+	}
+	m.mutex.Lock()
+	defer m.unlock()
+	if m.transition != nil {
+		m.transition.Cancel()
+		m.finishTransition()
+	}
+	previous := m.currentScene
+	if next == previous {
+		return nil
+	}
+	m.currentScene = next
+	switch transition {
+	case TransitionFade:
+		m.fade(previous, next, duration)
+	case TransitionSlideLeft, TransitionSlideRight, TransitionSlideUp, TransitionSlideDown:
+		m.slide(previous, next, transition, duration)
+	default:
+		m.swap(previous, next)
+	}
+	return nil
+}
+
+func (m *Movie) swap(previous, next *scenes.Scene) {
+	if previous != nil {
+		m.exit(previous)
+	}
+	m.enter(next)
+	clips.RefreshContainer(m.container)
+}
+
+// enter shows a scene, its enter handler is called once the lock of the
+// movie is released
+func (m *Movie) enter(scene *scenes.Scene) {
+	scene.GetContainer().Show()
+	m.hooks = append(m.hooks, scene.Enter)
+}
+
+// exit hides a scene, its exit handler is called once the lock of the movie
+// is released
+func (m *Movie) exit(scene *scenes.Scene) {
+	scene.GetContainer().Hide()
+	m.hooks = append(m.hooks, scene.Exit)
+}
+
+// unlock releases the lock of the movie and then calls the enter and exit
+// handlers of the scenes, so that the handlers may use the movie
+func (m *Movie) unlock() {
+	hooks := m.hooks
+	m.hooks = nil
+	m.mutex.Unlock()
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+// startTransition runs a tween as the transition, its steps and its end are
+// called by the shared ticker with the lock of the movie held and are left
+// out once the transition is finished or replaced
+func (m *Movie) startTransition(duration time.Duration, easing clips.Easing, step func(v float64)) {
+	var tween *clips.Tween
+	tween = clips.NewTween(duration, easing, func(v float64) {
+		m.mutex.Lock()
+		defer m.unlock()
+		if m.transition == tween {
+			step(v)
+		}
+	}).OnComplete(func() {
+		m.mutex.Lock()
+		defer m.unlock()
+		if m.transition == tween {
+			m.finishTransition()
+		}
+	})
+	m.transition = tween
+	tween.Start()
+}
+
+// finishTransition completes the running transition
+func (m *Movie) finishTransition() {
+	finish := m.finish
+	m.transition = nil
+	m.finish = nil
+	if finish != nil {
+		finish()
+	}
+	m.resetScenes()
+}
+
+// resetScenes puts all scenes back in place, only shows the current one and
+// removes the curtain of a fade
+func (m *Movie) resetScenes() {
+	for _, scene := range m.scenes {
+		c := scene.GetContainer()
+		c.Move(fyne.NewPos(0, 0))
+		if scene == m.currentScene {
+			c.Show()
+		} else {
+			c.Hide()
+		}
+	}
+	if m.curtain != nil {
+		m.container.Remove(m.curtain)
+		m.curtain = nil
+	}
+	clips.RefreshContainer(m.container)
+}
+
+func (m *Movie) fade(previous, next *scenes.Scene, duration time.Duration) {
+	curtain := canvas.NewRectangle(color.NRGBA{0, 0, 0, 0})
+	curtain.Resize(m.container.Size())
+	m.container.Add(curtain)
+	m.curtain = curtain
+	swapped := false
+	m.finish = func() {
+		if !swapped {
+			swapped = true
+			m.swap(previous, next)
+		}
+	}
+	m.startTransition(duration, clips.Linear, func(v float64) {
+		alpha := 2 * v
+		if v >= 0.5 {
+			if !swapped {
+				swapped = true
+				m.swap(previous, next)
+			}
+			alpha = 2 - 2*v
+		}
+		curtain.FillColor = color.NRGBA{0, 0, 0, uint8(alpha * 255)}
+		curtain.Refresh()
+	})
+}
+
+func (m *Movie) slide(previous, next *scenes.Scene, transition Transition, duration time.Duration) {
+	size := m.container.Size()
+	dx, dy := float32(0), float32(0)
+	switch transition {
+	case TransitionSlideLeft:
+		dx = size.Width
+	case TransitionSlideRight:
+		dx = -size.Width
+	case TransitionSlideUp:
+		dy = size.Height
+	case TransitionSlideDown:
+		dy = -size.Height
+	}
+	next.GetContainer().Move(fyne.NewPos(dx, dy))
+	m.enter(next)
+	m.finish = func() {
+		if previous != nil {
+			m.exit(previous)
+		}
+	}
+	m.startTransition(duration, clips.EaseInOutQuad, func(v float64) {
+		offset := float32(v)
+		if previous != nil {
+			previous.GetContainer().Move(fyne.NewPos(-dx*offset, -dy*offset))
+		}
+		next.GetContainer().Move(fyne.NewPos(dx*(1-offset), dy*(1-offset)))
+	})
+}
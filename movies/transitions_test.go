@@ -0,0 +1,142 @@
+package movies
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"github.com/mevdschee/fyne-mines/scenes"
+)
+
+func newTestMovie() *Movie {
+	m := New()
+	m.Add(scenes.New("first"))
+	m.Add(scenes.New("second"))
+	m.container.Resize(fyne.NewSize(100, 100))
+	return m
+}
+
+// waitForTransition waits until the transition of a movie is finished
+func waitForTransition(t *testing.T, m *Movie) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		m.mutex.Lock()
+		running := m.transition != nil
+		m.mutex.Unlock()
+		if !running {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("transition did not finish")
+}
+
+func TestFadeRemovesCurtain(t *testing.T) {
+	test.NewApp()
+	m := newTestMovie()
+	err := m.GotoSceneWithTransition("second", TransitionFade, 20*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a rectangle that is added during the fade must stay
+	m.mutex.Lock()
+	rect := canvas.NewRectangle(nil)
+	m.container.Add(rect)
+	m.mutex.Unlock()
+	waitForTransition(t, m)
+	objects := m.container.Objects
+	if objects[len(objects)-1] != rect {
+		t.Error("rectangle above the curtain was removed")
+	}
+	for _, o := range objects {
+		if o != rect {
+			if _, ok := o.(*canvas.Rectangle); ok {
+				t.Error("curtain was not removed")
+			}
+		}
+	}
+	if m.GetCurrentScene().GetName() != "second" || !m.scenes["second"].GetContainer().Visible() {
+		t.Error("second scene is not shown")
+	}
+}
+
+func TestGotoSceneDuringTransition(t *testing.T) {
+	test.NewApp()
+	m := newTestMovie()
+	names := []string{"second", "first"}
+	transitions := []Transition{TransitionFade, TransitionSlideLeft, TransitionSlideUp, TransitionNone}
+	for i := 0; i < 20; i++ {
+		err := m.GotoSceneWithTransition(names[i%2], transitions[i%len(transitions)], 5*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Duration(i%4) * time.Millisecond)
+	}
+	waitForTransition(t, m)
+	if m.curtain != nil {
+		t.Error("curtain is left after the transitions")
+	}
+	for name, scene := range m.scenes {
+		if scene.GetContainer().Visible() != (scene == m.GetCurrentScene()) {
+			t.Errorf("scene '%s' visible is %v", name, scene.GetContainer().Visible())
+		}
+	}
+}
+
+// TestHooksUseMovie calls back into the movie from the enter and exit
+// handlers of the scenes, they are called without the lock of the movie
+func TestHooksUseMovie(t *testing.T) {
+	test.NewApp()
+	m := newTestMovie()
+	entered := ""
+	m.scenes["second"].OnEnter(func() {
+		entered = m.GetCurrentScene().GetName()
+	})
+	m.scenes["first"].OnExit(func() {
+		err := m.GotoScene("second")
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	done := make(chan bool)
+	go func() {
+		err := m.GotoScene("second")
+		if err != nil {
+			t.Error(err)
+		}
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handler that uses the movie deadlocks")
+	}
+	if entered != "second" {
+		t.Errorf("current scene in the enter handler is '%s', want 'second'", entered)
+	}
+}
+
+// TestSlideKeepsPlaces lays out the movie during a slide, the scenes must
+// stay where the slide put them
+func TestSlideKeepsPlaces(t *testing.T) {
+	test.NewApp()
+	m := newTestMovie()
+	err := m.GotoSceneWithTransition("second", TransitionSlideLeft, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.container.Resize(fyne.NewSize(120, 120))
+	m.container.Refresh()
+	if pos := m.scenes["second"].GetContainer().Position(); pos != fyne.NewPos(100, 0) {
+		t.Errorf("sliding scene at %v after a layout, want (100,0)", pos)
+	}
+	err = m.GotoScene("first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos := m.scenes["second"].GetContainer().Position(); pos != fyne.NewPos(0, 0) {
+		t.Errorf("scene at %v after the slide, want (0,0)", pos)
+	}
+}
@@ -0,0 +1,66 @@
+package scenes
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/mevdschee/fyne-mines/clips"
+)
+
+// SetParent sets where the key events go that the scene does not handle
+func (s *Scene) SetParent(parent clips.KeyHandler) {
+	s.parent = parent
+}
+
+// OnKey sets the handler for the key events that the layers of the scene do
+// not handle, it returns whether it handled the event
+func (s *Scene) OnKey(handler func(ev *clips.Event) bool) {
+	s.onKey = handler
+}
+
+// HandleKey handles a key event that bubbles up from a layer, the tab key
+// moves the focus to the first clip of the next layer that has one
+func (s *Scene) HandleKey(ev *clips.Event) bool {
+	if s.onKey != nil && s.onKey(ev) {
+		return true
+	}
+	if ev.Key == fyne.KeyTab && s.FocusNext(ev.Clip, ev.Shift) {
+		return true
+	}
+	if s.parent != nil {
+		return s.parent.HandleKey(ev)
+	}
+	return false
+}
+
+// FocusNext gives the focus to the first (or last) focusable clip of the
+// layer after (or before) the layer of a clip, it wraps around
+func (s *Scene) FocusNext(clip *clips.Clip, backwards bool) bool {
+	ordered := s.GetOrderedLayers()
+	current := -1
+	for i, l := range ordered {
+		for _, c := range l.GetTabOrder() {
+			if c == clip {
+				current = i
+			}
+		}
+	}
+	if current < 0 {
+		return false
+	}
+	for n := 1; n <= len(ordered); n++ {
+		i := (current + n) % len(ordered)
+		if backwards {
+			i = (current - n + len(ordered)) % len(ordered)
+		}
+		order := ordered[i].GetTabOrder()
+		if len(order) == 0 {
+			continue
+		}
+		if backwards {
+			order[len(order)-1].Focus()
+		} else {
+			order[0].Focus()
+		}
+		return true
+	}
+	return false
+}
@@ -2,12 +2,14 @@ package scenes
 
 import (
 	"fmt"
+	"image"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"github.com/mevdschee/fyne-mines/clips"
 	"github.com/mevdschee/fyne-mines/layers"
 	"github.com/mevdschee/fyne-mines/sprites"
+	"golang.org/x/image/draw"
 )
 
 // Scene is a set of layers
@@ -16,6 +18,10 @@ type Scene struct {
 	name      string
 	layers    map[string]*layers.Layer
 	order     []string
+	onEnter   func()
+	onExit    func()
+	onKey     func(ev *clips.Event) bool
+	parent    clips.KeyHandler
 }
 
 // SceneJSON is a set of layers in JSON
@@ -34,6 +40,15 @@ func (s *Scene) GetLayers() map[string]*layers.Layer {
 	return s.layers
 }
 
+// GetOrderedLayers gets the layers of the scene in the order they are drawn
+func (s *Scene) GetOrderedLayers() []*layers.Layer {
+	ordered := []*layers.Layer{}
+	for _, name := range s.order {
+		ordered = append(ordered, s.layers[name])
+	}
+	return ordered
+}
+
 // New creates a new scene
 func New(name string) *Scene {
 	return &Scene{
@@ -44,8 +59,9 @@ func New(name string) *Scene {
 	}
 }
 
-// FromJSON creates a new scene from JSON
-func FromJSON(spriteMap sprites.SpriteMap, sceneJSON SceneJSON, parameters map[string]interface{}) (*Scene, error) {
+// FromJSON creates a new scene from JSON, its expressions may call the
+// functions
+func FromJSON(spriteMap sprites.SpriteMap, sceneJSON SceneJSON, parameters map[string]interface{}, functions *layers.Functions) (*Scene, error) {
 	scene := Scene{
 		container: container.NewStack(),
 		name:      sceneJSON.Name,
@@ -53,7 +69,7 @@ func FromJSON(spriteMap sprites.SpriteMap, sceneJSON SceneJSON, parameters map[s
 		order:     []string{},
 	}
 	for _, layerJSON := range sceneJSON.Layers {
-		layer, err := layers.FromJSON(spriteMap, layerJSON, parameters)
+		layer, err := layers.FromJSON(spriteMap, layerJSON, parameters, functions)
 		if err != nil {
 			return nil, err
 		}
@@ -72,9 +88,41 @@ func (s *Scene) Add(layer *layers.Layer) {
 	name := layer.GetName()
 	s.layers[name] = layer
 	s.order = append(s.order, name)
+	layer.SetParent(s)
 	s.container.Add(layer.GetContainer())
 }
 
+// MoveLayerToTop draws a layer of the scene above the others
+func (s *Scene) MoveLayerToTop(layer *layers.Layer) {
+	s.moveLayer(layer, len(s.order)-1)
+}
+
+// MoveLayerToBottom draws a layer of the scene below the others
+func (s *Scene) MoveLayerToBottom(layer *layers.Layer) {
+	s.moveLayer(layer, 0)
+}
+
+// moveLayer moves a layer to a position in the drawing order
+func (s *Scene) moveLayer(layer *layers.Layer, to int) {
+	from := -1
+	for i, name := range s.order {
+		if s.layers[name] == layer {
+			from = i
+		}
+	}
+	if from < 0 || from == to {
+		return
+	}
+	order := append(append([]string{}, s.order[:from]...), s.order[from+1:]...)
+	s.order = append(order[:to], append([]string{layer.GetName()}, order[to:]...)...)
+	objects := []fyne.CanvasObject{}
+	for _, name := range s.order {
+		objects = append(objects, s.layers[name].GetContainer())
+	}
+	s.container.Objects = objects
+	clips.RefreshContainer(s.container)
+}
+
 // GetClip gets a clip from the scene
 func (s *Scene) GetClip(layer, clip string, i int) (*clips.Clip, error) {
 	if l, ok := s.layers[layer]; ok {
@@ -82,3 +130,95 @@ func (s *Scene) GetClip(layer, clip string, i int) (*clips.Clip, error) {
 	}
 	return nil, fmt.Errorf("GetClip: layer '%s' not found", layer)
 }
+
+// GetClips gets the clips with a name from a layer of the scene
+func (s *Scene) GetClips(layer, clip string) ([]*clips.Clip, error) {
+	if l, ok := s.layers[layer]; ok {
+		return l.GetClipsByName(clip), nil
+	}
+	return nil, fmt.Errorf("GetClips: layer '%s' not found", layer)
+}
+
+// Set sets a value that the frame expressions of the layers may use
+func (s *Scene) Set(name string, value interface{}) error {
+	var first error
+	for _, layer := range s.GetOrderedLayers() {
+		err := layer.Set(name, value)
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// SetParameters lays out the layers again with other parameters and returns
+// the clips that were added
+func (s *Scene) SetParameters(parameters map[string]interface{}) ([]*clips.Clip, error) {
+	added := []*clips.Clip{}
+	for _, layer := range s.GetOrderedLayers() {
+		list, err := layer.SetParameters(parameters)
+		if err != nil {
+			return nil, fmt.Errorf("scene '%s': %v", s.name, err)
+		}
+		added = append(added, list...)
+	}
+	return added, nil
+}
+
+// OnEnter sets the handler that is called when the scene becomes active
+func (s *Scene) OnEnter(handler func()) {
+	s.onEnter = handler
+}
+
+// Enter calls the enter handler, the movie shows the scene
+func (s *Scene) Enter() {
+	if s.onEnter != nil {
+		s.onEnter()
+	}
+}
+
+// OnExit sets the handler that is called when the scene is no longer active
+func (s *Scene) OnExit(handler func()) {
+	s.onExit = handler
+}
+
+// Exit calls the exit handler, the movie hides the scene
+func (s *Scene) Exit() {
+	if s.onExit != nil {
+		s.onExit()
+	}
+}
+
+// Validate checks a scene in JSON and reports every problem with its JSON path
+func Validate(spriteMap sprites.SpriteMap, sceneJSON SceneJSON, parameters map[string]interface{}, functions *layers.Functions, bounds image.Rectangle, path string) []error {
+	errs := []error{}
+	if sceneJSON.Name == "" {
+		errs = append(errs, fmt.Errorf("%s.name: scene has no name", path))
+	}
+	names := map[string]bool{}
+	for i, layerJSON := range sceneJSON.Layers {
+		layerPath := fmt.Sprintf("%s.layers[%d]", path, i)
+		if names[layerJSON.Name] {
+			errs = append(errs, fmt.Errorf("%s.name: duplicate layer name '%s'", layerPath, layerJSON.Name))
+		}
+		names[layerJSON.Name] = true
+		errs = append(errs, layers.Validate(spriteMap, layerJSON, parameters, functions, bounds, layerPath)...)
+	}
+	return errs
+}
+
+// GetBounds gets the rectangle that contains all layers (in unscaled pixels)
+func (s *Scene) GetBounds() image.Rectangle {
+	bounds := image.Rectangle{}
+	for _, name := range s.order {
+		bounds = bounds.Union(s.layers[name].GetBounds())
+	}
+	return bounds
+}
+
+// Draw draws the layers of the scene in order without a GPU
+func (s *Scene) Draw(dst draw.Image) {
+	for _, name := range s.order {
+		s.layers[name].Draw(dst)
+	}
+}
@@ -0,0 +1,49 @@
+package scenes
+
+import (
+	"encoding/json"
+	"image"
+	"os"
+	"testing"
+
+	"github.com/mevdschee/fyne-mines/layers"
+	"github.com/mevdschee/fyne-mines/sprites"
+)
+
+// TestRenderWithoutApp creates and draws a scene without a running app, as
+// when a movie is only rendered, so this package does not use the test app
+func TestRenderWithoutApp(t *testing.T) {
+	imageData, err := os.ReadFile("../winxpskin.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spriteMap, err := sprites.NewSpriteMap(imageData, `[
+		{"name":"icons","x":0,"y":0,"width":16,"height":16,"count":2,"names":["empty","one"]}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sceneJSON := SceneJSON{}
+	err = json.Unmarshal([]byte(`{"name":"game","layers":[{"name":"board","clips":[
+		{"sprite":"icons","name":"icons","repeat":"w*h","x":"(i%w)*16","y":"i/w*16","frame":"tile"}]}]}`), &sceneJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := FromJSON(spriteMap, sceneJSON, map[string]interface{}{"w": 2, "h": 1, "s": 1}, layers.NewFunctions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.SetParameters(map[string]interface{}{"w": 3, "h": 1, "s": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Set("tile", "one")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.MoveLayerToTop(s.GetLayers()["board"])
+	dst := image.NewNRGBA(s.GetBounds())
+	s.Draw(dst)
+	if dst.Bounds().Dx() != 48 {
+		t.Errorf("drawn %v, want 3 icons", dst.Bounds())
+	}
+}
@@ -0,0 +1,194 @@
+package sprites
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// packedRect is a rectangle in a TexturePacker or Aseprite JSON file
+type packedRect struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// packedFrame is a frame in a TexturePacker or Aseprite JSON file
+type packedFrame struct {
+	Filename         string     `json:"filename"`
+	Frame            packedRect `json:"frame"`
+	Rotated          bool       `json:"rotated"`
+	Trimmed          bool       `json:"trimmed"`
+	SpriteSourceSize packedRect `json:"spriteSourceSize"`
+	SourceSize       packedRect `json:"sourceSize"`
+	Duration         int        `json:"duration"`
+}
+
+// packedTag is a frame tag in an Aseprite JSON file
+type packedTag struct {
+	Name string `json:"name"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// packedSheet is a TexturePacker or Aseprite JSON file
+type packedSheet struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		FrameTags []packedTag `json:"frameTags"`
+	} `json:"meta"`
+}
+
+var numberedFrame = regexp.MustCompile(`^(.*?)[ _\-/.]?([0-9]+)$`)
+
+// NewSpriteMapFromTexturePacker creates a new sprite map from a TexturePacker
+// JSON file (hash or array), frames named "name_0", "name_1" become one sprite
+func NewSpriteMapFromTexturePacker(imageData []byte, jsondata string) (SpriteMap, error) {
+	image, frames, _, err := parsePacked(imageData, jsondata)
+	if err != nil {
+		return nil, err
+	}
+	return groupPacked(&image, frames), nil
+}
+
+// NewSpriteMapFromAseprite creates a new sprite map from an Aseprite JSON
+// file, every frame tag becomes a sprite, without tags frames are grouped
+// by name as with TexturePacker
+func NewSpriteMapFromAseprite(imageData []byte, jsondata string) (SpriteMap, error) {
+	image, frames, tags, err := parsePacked(imageData, jsondata)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return groupPacked(&image, frames), nil
+	}
+	spriteMap := SpriteMap{}
+	for _, tag := range tags {
+		if tag.From < 0 || tag.To >= len(frames) || tag.From > tag.To {
+			return nil, fmt.Errorf("frame tag '%s' has invalid range %d-%d", tag.Name, tag.From, tag.To)
+		}
+		spriteMap[tag.Name] = newPackedSprite(&image, tag.Name, frames[tag.From:tag.To+1])
+	}
+	return spriteMap, nil
+}
+
+func parsePacked(imageData []byte, jsondata string) (image.Image, []packedFrame, []packedTag, error) {
+	image, err := png.Decode(bytes.NewReader(imageData))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sheet := packedSheet{}
+	err = json.Unmarshal([]byte(jsondata), &sheet)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	frames, err := parsePackedFrames(sheet.Frames)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return image, frames, sheet.Meta.FrameTags, nil
+}
+
+// parsePackedFrames reads the frames as an array or as a hash, keeping the
+// order of the keys in the hash as frame tags refer to frames by index
+func parsePackedFrames(data json.RawMessage) ([]packedFrame, error) {
+	frames := []packedFrame{}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no frames found")
+	}
+	if data[0] == '[' {
+		err := json.Unmarshal(data, &frames)
+		return frames, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		frame := packedFrame{}
+		err = decoder.Decode(&frame)
+		if err != nil {
+			return nil, fmt.Errorf("frame '%v': %v", token, err)
+		}
+		frame.Filename = fmt.Sprint(token)
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
+
+// groupPacked groups numbered frames into sprites by the name before the number
+func groupPacked(image *image.Image, frames []packedFrame) SpriteMap {
+	groups := map[string][]packedFrame{}
+	numbers := map[string]int{}
+	names := []string{}
+	for _, frame := range frames {
+		name := frameName(frame.Filename)
+		if match := numberedFrame.FindStringSubmatch(name); match != nil && match[1] != "" {
+			numbers[frame.Filename], _ = strconv.Atoi(match[2])
+			name = match[1]
+		}
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		groups[name] = append(groups[name], frame)
+	}
+	spriteMap := SpriteMap{}
+	for _, name := range names {
+		group := groups[name]
+		sort.SliceStable(group, func(i, j int) bool {
+			return numbers[group[i].Filename] < numbers[group[j].Filename]
+		})
+		spriteMap[name] = newPackedSprite(image, name, group)
+	}
+	return spriteMap
+}
+
+func newPackedSprite(image *image.Image, name string, packedFrames []packedFrame) *Sprite {
+	sprite := &Sprite{
+		Image:  image,
+		Name:   name,
+		Count:  len(packedFrames),
+		Frames: []*Frame{},
+	}
+	for _, f := range packedFrames {
+		frame := &Frame{
+			Name:     frameName(f.Filename),
+			X:        f.Frame.X,
+			Y:        f.Frame.Y,
+			Width:    f.Frame.W,
+			Height:   f.Frame.H,
+			OffsetX:  f.SpriteSourceSize.X,
+			OffsetY:  f.SpriteSourceSize.Y,
+			Rotated:  f.Rotated,
+			Duration: f.Duration,
+		}
+		width, height := f.SourceSize.W, f.SourceSize.H
+		if width == 0 || height == 0 {
+			width, height = frame.OffsetX+frame.Width, frame.OffsetY+frame.Height
+		}
+		if width > sprite.Width {
+			sprite.Width = width
+		}
+		if height > sprite.Height {
+			sprite.Height = height
+		}
+		sprite.Frames = append(sprite.Frames, frame)
+	}
+	return sprite
+}
+
+func frameName(filename string) string {
+	return filename[:len(filename)-len(path.Ext(filename))]
+}
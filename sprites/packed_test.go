@@ -0,0 +1,106 @@
+package sprites
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// the sprites of texturepacker_hash.json and texturepacker_array.json, the
+// frames of a sprite are ordered by their number
+var texturePackerSprites = []string{
+	"door 10x4: door (0,8) 10x4 +0,0 rotated 0ms",
+	"walk 8x8: walk_0 (0,0) 8x8 +0,0 0ms, walk_1 (8,0) 6x5 +1,2 0ms",
+}
+
+func TestPackedSprites(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		json     string
+		aseprite bool
+		want     []string
+		err      string
+	}{
+		{name: "texturepacker hash", file: "texturepacker_hash.json", want: texturePackerSprites},
+		{name: "texturepacker array", file: "texturepacker_array.json", want: texturePackerSprites},
+		{name: "aseprite without tags", file: "texturepacker_hash.json", aseprite: true, want: texturePackerSprites},
+		{name: "aseprite tags", file: "aseprite.json", aseprite: true, want: []string{
+			"idle 4x4: hero 2 (0,0) 4x4 +0,0 100ms",
+			"run 4x4: hero 0 (4,0) 4x4 +0,0 200ms, hero 1 (8,0) 3x2 +1,1 300ms",
+		}},
+		{name: "aseprite tag out of range", aseprite: true,
+			json: `{"frames":[{"filename":"a","frame":{"x":0,"y":0,"w":1,"h":1}}],"meta":{"frameTags":[{"name":"run","from":0,"to":1}]}}`,
+			err:  "frame tag 'run' has invalid range 0-1"},
+		{name: "no frames", json: `{"meta":{}}`, err: "no frames found"},
+		{name: "invalid frame", json: `{"frames":{"a":{"frame":[]}}}`,
+			err: "frame 'a': json: cannot unmarshal array into Go struct field packedFrame.frame of type sprites.packedRect"},
+	}
+	imageData := newPackedImage(t, 16, 18)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.json
+			if tt.file != "" {
+				content, err := os.ReadFile(filepath.Join("testdata", tt.file))
+				if err != nil {
+					t.Fatal(err)
+				}
+				data = string(content)
+			}
+			newSpriteMap := NewSpriteMapFromTexturePacker
+			if tt.aseprite {
+				newSpriteMap = NewSpriteMapFromAseprite
+			}
+			spriteMap, err := newSpriteMap(imageData, data)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("got error %v, want '%s'", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := describeSprites(spriteMap)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got sprites:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+			checkErrors(t, Validate(spriteMap), []string{})
+		})
+	}
+}
+
+// newPackedImage creates the PNG data of an empty sprite sheet
+func newPackedImage(t *testing.T, width, height int) []byte {
+	buf := &bytes.Buffer{}
+	err := png.Encode(buf, image.NewNRGBA(image.Rect(0, 0, width, height)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// describeSprites describes the sprites of a sprite map by name, a line
+// per sprite with its frames
+func describeSprites(spriteMap SpriteMap) []string {
+	lines := []string{}
+	for name, sprite := range spriteMap {
+		frames := []string{}
+		for _, f := range sprite.Frames {
+			frame := fmt.Sprintf("%s (%d,%d) %dx%d +%d,%d", f.Name, f.X, f.Y, f.Width, f.Height, f.OffsetX, f.OffsetY)
+			if f.Rotated {
+				frame += " rotated"
+			}
+			frames = append(frames, fmt.Sprintf("%s %dms", frame, f.Duration))
+		}
+		lines = append(lines, fmt.Sprintf("%s %dx%d: %s", name, sprite.Width, sprite.Height, strings.Join(frames, ", ")))
+	}
+	sort.Strings(lines)
+	return lines
+}
@@ -3,8 +3,10 @@ package sprites
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"image"
 	"image/png"
+	"sort"
 )
 
 // SpriteMap is a map of sprites
@@ -23,6 +25,30 @@ type Sprite struct {
 	Count   int          `json:"count"`
 	Grid    int          `json:"grid"`
 	Gap     int          `json:"gap,omitempty"`
+	Fill    string       `json:"fill,omitempty"`
+	Names   []string     `json:"names,omitempty"`
+	Frames  []*Frame     `json:"frames,omitempty"`
+}
+
+// the ways the edge and centre slices of a nine-slice sprite fill their size
+const (
+	FillStretch = "stretch"
+	FillTile    = "tile"
+)
+
+// Frame is a rectangle on the sprite sheet that is drawn at an offset
+// within the sprite, it may be stored rotated 90 degrees clockwise and
+// may have a duration in milliseconds for animation
+type Frame struct {
+	Name     string `json:"name,omitempty"`
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	OffsetX  int    `json:"offsetX,omitempty"`
+	OffsetY  int    `json:"offsetY,omitempty"`
+	Rotated  bool   `json:"rotated,omitempty"`
+	Duration int    `json:"duration,omitempty"`
 }
 
 // NewSpriteMap creates a new sprite map
@@ -31,15 +57,168 @@ func NewSpriteMap(imageData []byte, jsondata string) (SpriteMap, error) {
 	if err != nil {
 		return nil, err
 	}
+	spriteMap, err := ParseSpriteMap(jsondata)
+	if err != nil {
+		return nil, err
+	}
+	for _, sprite := range spriteMap {
+		sprite.Image = &image
+	}
+	return spriteMap, nil
+}
+
+// ParseSpriteMap creates a new sprite map without an image
+func ParseSpriteMap(jsondata string) (SpriteMap, error) {
 	sprites := []*Sprite{}
 	spriteMap := SpriteMap{}
-	err = json.Unmarshal([]byte(jsondata), &sprites)
+	err := json.Unmarshal([]byte(jsondata), &sprites)
 	if err != nil {
 		return nil, err
 	}
 	for _, sprite := range sprites {
-		sprite.Image = &image
+		if sprite.Width == 0 && sprite.Height == 0 {
+			for _, frame := range sprite.Frames {
+				if frame.OffsetX+frame.Width > sprite.Width {
+					sprite.Width = frame.OffsetX + frame.Width
+				}
+				if frame.OffsetY+frame.Height > sprite.Height {
+					sprite.Height = frame.OffsetY + frame.Height
+				}
+			}
+		}
 		spriteMap[sprite.Name] = sprite
 	}
 	return spriteMap, nil
 }
+
+// Validate checks the frames of the sprites and reports every problem with
+// its JSON path, the frames are checked to lie on the image when there is one
+func Validate(spriteMap SpriteMap) []error {
+	errs := []error{}
+	names := []string{}
+	for name := range spriteMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		errs = append(errs, spriteMap[name].validate("sprites."+name)...)
+	}
+	return errs
+}
+
+// validate checks the geometry of the frames of a sprite as it is in JSON
+// and against the image when there is one
+func (s *Sprite) validate(path string) []error {
+	errs := []error{}
+	if s.Fill != "" && s.Fill != FillStretch && s.Fill != FillTile {
+		errs = append(errs, fmt.Errorf("%s.fill: unknown fill '%s'", path, s.Fill))
+	}
+	// listed frames have a path of their own, others are laid out on a grid
+	listed := len(s.Frames) > 0 && !s.IsSliced()
+	frames := s.GetFrames()
+	if s.IsSliced() {
+		for i := 0; i < 3; i++ {
+			if s.Widths[i] < 0 || s.Heights[i] < 0 {
+				errs = append(errs, fmt.Errorf("%s: slices must not have a negative width or height", path))
+				break
+			}
+		}
+		frames = s.GetSlicedFrames()
+	} else if !listed && s.Count > 0 && (s.Width <= 0 || s.Height <= 0) {
+		errs = append(errs, fmt.Errorf("%s: sprite must have a width and a height", path))
+	}
+	if len(frames) == 0 {
+		errs = append(errs, fmt.Errorf("%s: sprite has no frames", path))
+	}
+	if !listed && len(s.Names) > len(frames) {
+		errs = append(errs, fmt.Errorf("%s.names: %d names for %d frames", path, len(s.Names), len(frames)))
+	}
+	for i, f := range frames {
+		width, height := f.Width, f.Height
+		if f.Rotated {
+			width, height = height, width
+		}
+		rect := image.Rect(f.X, f.Y, f.X+width, f.Y+height)
+		framePath := fmt.Sprintf("%s: frame %d", path, i)
+		if listed {
+			framePath = fmt.Sprintf("%s.frames[%d]", path, i)
+		}
+		switch {
+		case f.Width <= 0 || f.Height <= 0:
+			errs = append(errs, fmt.Errorf("%s: frame must have a width and a height", framePath))
+		case f.X < 0 || f.Y < 0:
+			errs = append(errs, fmt.Errorf("%s: frame at %v must not have a negative position", framePath, rect))
+		case listed && (f.OffsetX < 0 || f.OffsetY < 0 || f.OffsetX+f.Width > s.Width || f.OffsetY+f.Height > s.Height):
+			errs = append(errs, fmt.Errorf("%s: frame at offset (%d,%d) does not fit the sprite of %dx%d", framePath, f.OffsetX, f.OffsetY, s.Width, s.Height))
+		}
+		if s.Image != nil && !rect.In((*s.Image).Bounds()) {
+			errs = append(errs, fmt.Errorf("%s: frame at %v is outside the image %v", framePath, rect, (*s.Image).Bounds()))
+		}
+	}
+	return errs
+}
+
+// GetFrames gets the frames of the sprite, either the explicit list or the
+// frames that are laid out on a grid using count, grid and gap (named
+// using names)
+func (s *Sprite) GetFrames() []*Frame {
+	if len(s.Frames) > 0 {
+		return s.Frames
+	}
+	frames := []*Frame{}
+	grid := s.Grid
+	if grid == 0 {
+		grid = s.Count
+	}
+	for i := 0; i < s.Count; i++ {
+		name := ""
+		if i < len(s.Names) {
+			name = s.Names[i]
+		}
+		frames = append(frames, &Frame{
+			Name:   name,
+			X:      s.X + (i%grid)*(s.Width+s.Gap),
+			Y:      s.Y + (i/grid)*(s.Height+s.Gap),
+			Width:  s.Width,
+			Height: s.Height,
+		})
+	}
+	return frames
+}
+
+// IsSliced returns whether the sprite has nine-slice widths and heights
+func (s *Sprite) IsSliced() bool {
+	return s.Widths[0]+s.Widths[1]+s.Widths[2] > 0
+}
+
+// GetSlicedFrames gets the frames of a nine-slice sprite, each frame holds
+// the nine slices (with the gap between them) and the frames are laid out on
+// a grid using count, grid and gap (named using names), without a count
+// there is one frame
+func (s *Sprite) GetSlicedFrames() []*Frame {
+	width := s.Widths[0] + s.Widths[1] + s.Widths[2] + 2*s.Gap
+	height := s.Heights[0] + s.Heights[1] + s.Heights[2] + 2*s.Gap
+	count := s.Count
+	if count == 0 {
+		count = 1
+	}
+	grid := s.Grid
+	if grid == 0 {
+		grid = count
+	}
+	frames := []*Frame{}
+	for i := 0; i < count; i++ {
+		name := ""
+		if i < len(s.Names) {
+			name = s.Names[i]
+		}
+		frames = append(frames, &Frame{
+			Name:   name,
+			X:      s.X + (i%grid)*(width+s.Gap),
+			Y:      s.Y + (i/grid)*(height+s.Gap),
+			Width:  width,
+			Height: height,
+		})
+	}
+	return frames
+}
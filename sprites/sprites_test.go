@@ -0,0 +1,54 @@
+package sprites
+
+import (
+	"image"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	spriteMap, err := ParseSpriteMap(`[
+		{"name":"digits","x":0,"y":0,"width":11,"height":21,"count":2,"names":["0","1","2"]},
+		{"name":"empty","x":0,"y":0,"count":1},
+		{"name":"field","x":0,"y":0,"widths":[12,1,12],"heights":[11,1,11],"fill":"wrap"},
+		{"name":"packed","frames":[
+			{"x":0,"y":0,"width":4,"height":4},
+			{"x":-1,"y":0,"width":4,"height":4},
+			{"x":0,"y":0,"width":0,"height":4}]}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"sprites.digits.names: 3 names for 2 frames",
+		"sprites.empty: sprite must have a width and a height",
+		"sprites.empty: frame 0: frame must have a width and a height",
+		"sprites.field.fill: unknown fill 'wrap'",
+		"sprites.packed.frames[1]: frame at (-1,0)-(3,4) must not have a negative position",
+		"sprites.packed.frames[2]: frame must have a width and a height",
+	}
+	checkErrors(t, Validate(spriteMap), want)
+
+	// with an image the frames must also lie on it
+	var img image.Image = image.NewNRGBA(image.Rect(0, 0, 16, 16))
+	spriteMap["digits"].Image = &img
+	spriteMap["digits"].Names = nil
+	want = []string{
+		"sprites.digits: frame 0: frame at (0,0)-(11,21) is outside the image (0,0)-(16,16)",
+		"sprites.digits: frame 1: frame at (11,0)-(22,21) is outside the image (0,0)-(16,16)",
+	}
+	delete(spriteMap, "empty")
+	delete(spriteMap, "field")
+	delete(spriteMap, "packed")
+	checkErrors(t, Validate(spriteMap), want)
+}
+
+func checkErrors(t *testing.T, errs []error, want []string) {
+	t.Helper()
+	got := []string{}
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
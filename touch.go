@@ -0,0 +1,120 @@
+package main
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/mevdschee/fyne-mines/clips"
+)
+
+// setTouchHandlers binds a tap on the button to a restart
+func (g *game) setTouchHandlers() {
+	button := g.getClips("fg", "button")[0]
+	button.OnTap(func() {
+		g.locked(func() {
+			g.restart()
+		})
+	})
+}
+
+// setTouchTileHandlers binds taps on a tile, a tap digs or flags depending on
+// the mode and a long press (or secondary tap) does the other, dragging pans
+// the board or, in flag mode, flags every tile it passes, the tile is found
+// by the index of the clip when a handler is called
+func (g *game) setTouchTileHandlers(icon *clips.Clip) {
+	tile := func() (int, int) {
+		i := icon.GetIndex()
+		return i % g.c.width, i / g.c.width
+	}
+	icon.OnTap(func() {
+		g.locked(func() {
+			x, y := tile()
+			g.touchTile(x, y, g.flagMode)
+		})
+	})
+	icon.OnLongPress(func() {
+		g.locked(func() {
+			x, y := tile()
+			g.touchTile(x, y, !g.flagMode)
+		})
+	})
+	icon.OnTapSecondary(func() {
+		g.locked(func() {
+			x, y := tile()
+			g.touchTile(x, y, !g.flagMode)
+		})
+	})
+	icon.OnDrag(func(ev *clips.Event) {
+		g.locked(func() {
+			x, y := tile()
+			g.touchDrag(x, y, ev)
+		})
+	})
+	icon.OnDragEnd(func() {
+		g.locked(func() {
+			g.flagged = nil
+		})
+	})
+}
+
+// touchTile digs or flags a tile that was touched
+func (g *game) touchTile(x, y int, flag bool) {
+	if g.board.IsOver() {
+		return
+	}
+	if flag {
+		g.toggleMark(x, y)
+	} else {
+		g.dig(x, y)
+	}
+}
+
+// touchDrag flags the tiles that are dragged over in flag mode and pans the
+// board otherwise, the position of the event is relative to the first tile
+func (g *game) touchDrag(px, py int, ev *clips.Event) {
+	if !g.flagMode {
+		g.scrollBy(-ev.Delta.DX, -ev.Delta.DY)
+		return
+	}
+	if g.board.IsOver() {
+		return
+	}
+	tile := g.tileSize()
+	if tile <= 0 {
+		return
+	}
+	x := px + int(math.Floor(float64(ev.Position.X/tile)))
+	y := py + int(math.Floor(float64(ev.Position.Y/tile)))
+	if x < 0 || y < 0 || x >= g.c.width || y >= g.c.height {
+		return
+	}
+	if g.flagged == nil {
+		g.flagged = map[int]bool{}
+	}
+	if g.flagged[y*g.c.width+x] {
+		return
+	}
+	g.flagged[y*g.c.width+x] = true
+	if !g.board.GetTile(x, y).Marked {
+		g.toggleMark(x, y)
+	}
+}
+
+// newModeToggle creates the button that switches taps between dig and flag
+func (g *game) newModeToggle() fyne.CanvasObject {
+	var toggle *widget.Button
+	label := func() string {
+		if g.flagMode {
+			return "Mode: Flag"
+		}
+		return "Mode: Dig"
+	}
+	toggle = widget.NewButton(label(), func() {
+		g.locked(func() {
+			g.flagMode = !g.flagMode
+			toggle.SetText(label())
+		})
+	})
+	return toggle
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"github.com/mevdschee/fyne-mines/clips"
+)
+
+// the board is scrolled when it is larger than this (in screen pixels)
+const (
+	maxViewportWidth  = 1600
+	maxViewportHeight = 900
+)
+
+// the range of the scale of the board
+const (
+	minScale = 1
+	maxScale = 4
+)
+
+// fixedLayout gives all objects a fixed size
+type fixedLayout struct {
+	size fyne.Size
+}
+
+func (l *fixedLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	for _, o := range objects {
+		o.Move(fyne.NewPos(0, 0))
+		o.Resize(l.size)
+	}
+}
+
+func (l *fixedLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	return l.size
+}
+
+// newContent puts the movie in a scroll container, the movie and its scenes
+// stay together so that transitions and the order of the layers still work
+func (g *game) newContent() fyne.CanvasObject {
+	g.viewport = &fixedLayout{}
+	g.scroll = container.NewScroll(container.New(g.viewport, g.movie.GetContainer()))
+	g.layoutContent()
+	return g.scroll
+}
+
+// layoutContent sizes the content for the bounds of the scene at the scale
+// of the board, the scroll container is not larger than the screen allows
+func (g *game) layoutContent() {
+	scale := float32(g.c.scale)
+	bounds := g.movie.GetCurrentScene().GetBounds()
+	g.viewport.size = fyne.NewSize(float32(bounds.Max.X)*scale, float32(bounds.Max.Y)*scale)
+	size := g.viewport.size
+	if size.Width > maxViewportWidth {
+		size.Width = maxViewportWidth
+	}
+	if size.Height > maxViewportHeight {
+		size.Height = maxViewportHeight
+	}
+	g.scroll.SetMinSize(size)
+}
+
+// scrollBy pans the board (in screen pixels)
+func (g *game) scrollBy(dx, dy float32) {
+	if g.scroll == nil {
+		return
+	}
+	g.scroll.Offset = fyne.NewPos(g.scroll.Offset.X+dx, g.scroll.Offset.Y+dy)
+	g.scroll.Refresh()
+}
+
+// startPan starts panning the board with the middle button on a tile, the
+// board is then moved by dragging
+func (g *game) startPan() {
+	g.panning = true
+}
+
+// onScroll zooms the board when control is held and scrolls it otherwise
+func (g *game) onScroll(ev *clips.Event) {
+	if ev.Control {
+		if ev.Delta.DY > 0 {
+			g.zoom(1)
+		} else if ev.Delta.DY < 0 {
+			g.zoom(-1)
+		}
+		return
+	}
+	if g.scroll != nil {
+		g.scroll.Scrolled(&fyne.ScrollEvent{Scrolled: ev.Delta})
+	}
+}
+
+// tileSize gets the size of a tile of the board (in screen pixels)
+func (g *game) tileSize() float32 {
+	icons := g.getClips("board", "icons")
+	if len(icons) == 0 {
+		return 0
+	}
+	return float32(icons[0].GetBounds().Dx() * g.c.scale)
+}
+
+// scrollToTile scrolls the board as little as possible to show a tile
+func (g *game) scrollToTile(x, y int) {
+	if g.scroll == nil {
+		return
+	}
+	icons := g.getClips("board", "icons")
+	if y*g.c.width+x >= len(icons) {
+		return
+	}
+	scale := float32(g.c.scale)
+	bounds := icons[y*g.c.width+x].GetBounds()
+	left, top := float32(bounds.Min.X)*scale, float32(bounds.Min.Y)*scale
+	right, bottom := float32(bounds.Max.X)*scale, float32(bounds.Max.Y)*scale
+	offset := g.scroll.Offset
+	size := g.scroll.Size()
+	if left < offset.X {
+		offset.X = left
+	} else if right > offset.X+size.Width {
+		offset.X = right - size.Width
+	}
+	if top < offset.Y {
+		offset.Y = top
+	} else if bottom > offset.Y+size.Height {
+		offset.Y = bottom - size.Height
+	}
+	if offset != g.scroll.Offset {
+		g.scroll.Offset = offset
+		g.scroll.Refresh()
+	}
+}
+
+// zoom changes the scale of the board by a step within its range
+func (g *game) zoom(step int) {
+	scale := g.c.scale + step
+	if scale < minScale || scale > maxScale {
+		return
+	}
+	g.setScale(scale)
+}
+
+// setScale zooms the board by laying out the movie at another scale
+func (g *game) setScale(scale int) {
+	if scale < 1 || scale == g.c.scale {
+		return
+	}
+	offset := g.scroll.Offset
+	ratio := float32(scale) / float32(g.c.scale)
+	g.c.scale = scale
+	err := g.setParameters()
+	if err != nil {
+		log.Println(err)
+		g.rebuild()
+	}
+	g.scroll.Offset = fyne.NewPos(offset.X*ratio, offset.Y*ratio)
+	g.scroll.Refresh()
+}